@@ -2,14 +2,40 @@ package color
 
 import (
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 )
 
-type Color int
+// Color is a color to decorate a string.
+// It holds a foreground and a background, each of them is one of
+// basic 8 colors, 256 colors, or 24-bit RGB color, and text attributes such as bold.
+// The zero value means no color.
+type Color uint64
 
 const escape = "\x1b"
 
+// A Color is stored in bits as below:
+//
+//	bit 0-25:  foreground
+//	bit 26-51: background
+//	bit 52-:   attributes
+//
+// Each of foreground and background is 2 bits of its kind and 24 bits of its value.
 const (
-	Black Color = iota + 30
+	valueBits = 24
+	slotBits  = valueBits + 2
+	valueMask = 1<<valueBits - 1
+	slotMask  = 1<<slotBits - 1
+	attrShift = slotBits * 2
+
+	kindBasic = 1 << valueBits
+	kind256   = 2 << valueBits
+	kindRGB   = 3 << valueBits
+)
+
+const (
+	Black Color = kindBasic + iota
 	Red
 	Green
 	Yellow
@@ -19,10 +45,261 @@ const (
 	White
 )
 
-func (c Color) sequence() int {
-	return int(c)
+// Text attributes. They can be combined with a color using With,
+// e.g. color.Red.With(color.Bold).
+const (
+	Bold Color = 1 << (attrShift + iota)
+	Faint
+	Italic
+	Underline
+	Reverse
+)
+
+// attributeSequences are SGR parameters of each attribute, in the order of the attribute bits.
+var attributeSequences = []string{"1", "2", "3", "4", "7"}
+
+// Color256 returns a color in the 256-color palette.
+func Color256(n uint8) Color {
+	return kind256 | Color(n)
+}
+
+// RGB returns a 24-bit (truecolor) color.
+func RGB(r, g, b uint8) Color {
+	return kindRGB | Color(r)<<16 | Color(g)<<8 | Color(b)
+}
+
+// Background returns a color which has only the background of the given color.
+func Background(c Color) Color {
+	return Color(0).On(c)
+}
+
+// On returns a color whose foreground is c's one and the background is bg's foreground.
+// e.g. color.White.On(color.Red) is white characters on red background.
+func (c Color) On(bg Color) Color {
+	return c&^(slotMask<<slotBits) | (bg&slotMask)<<slotBits
+}
+
+// With returns a color combining c and other.
+// Attributes of both are kept, and the foreground and background of other
+// take precedence over c's ones when they are set.
+func (c Color) With(other Color) Color {
+	ret := c | other.attributes()
+	if fg := other.foreground(); fg != 0 {
+		ret = ret&^slotMask | fg
+	}
+	if bg := other.background(); bg != 0 {
+		ret = ret.On(bg)
+	}
+
+	return ret
+}
+
+func (c Color) foreground() Color {
+	return c & slotMask
+}
+
+func (c Color) background() Color {
+	return c >> slotBits & slotMask
+}
+
+func (c Color) attributes() Color {
+	return c >> attrShift << attrShift
+}
+
+// Mode is a color capability of the terminal.
+type Mode int
+
+const (
+	// ModeBasic is a terminal which supports only basic 8 colors.
+	ModeBasic Mode = iota
+	// Mode256 is a terminal which supports 256 colors.
+	Mode256
+	// ModeTrueColor is a terminal which supports 24-bit RGB colors.
+	ModeTrueColor
+)
+
+// DetectMode returns the color mode the terminal supports, based on COLORTERM and TERM.
+func DetectMode() Mode {
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ModeTrueColor
+	}
+
+	term := strings.ToLower(os.Getenv("TERM"))
+	switch {
+	case strings.Contains(term, "truecolor"), strings.Contains(term, "24bit"), strings.Contains(term, "direct"):
+		return ModeTrueColor
+	case strings.Contains(term, "256color"):
+		return Mode256
+	}
+
+	return ModeBasic
 }
 
+// mode is used in Apply. Colors which are not supported by the mode
+// are converted into the nearest supported one.
+var mode = DetectMode()
+
+// SetMode overrides the color mode detected by environment variables.
+func SetMode(m Mode) {
+	mode = m
+}
+
+// sequence returns SGR parameters to apply c in the given mode, e.g. "31", "1;31" or "38;5;208".
+func (c Color) sequence(m Mode) string {
+	params := []string{}
+	for i, seq := range attributeSequences {
+		if c&(1<<(attrShift+i)) != 0 {
+			params = append(params, seq)
+		}
+	}
+
+	if fg := c.foreground(); fg != 0 {
+		params = append(params, fg.slotSequence(m, false))
+	}
+
+	if bg := c.background(); bg != 0 {
+		params = append(params, bg.slotSequence(m, true))
+	}
+
+	return strings.Join(params, ";")
+}
+
+// slotSequence returns SGR parameters of a foreground or background.
+func (c Color) slotSequence(m Mode, background bool) string {
+	base := 30
+	if background {
+		base = 40
+	}
+
+	c = c.downgrade(m)
+	value := int(c & valueMask)
+	switch c &^ valueMask {
+	case kind256:
+		return fmt.Sprintf("%d;5;%d", base+8, value)
+	case kindRGB:
+		return fmt.Sprintf("%d;2;%d;%d;%d", base+8, value>>16&0xff, value>>8&0xff, value&0xff)
+	default:
+		return strconv.Itoa(base + value)
+	}
+}
+
+// downgrade converts a single color into the nearest one which is supported in the mode.
+func (c Color) downgrade(m Mode) Color {
+	kind := c &^ valueMask
+	switch {
+	case kind == kindRGB && m == Mode256:
+		r, g, b := c.rgb()
+		return Color256(nearest256(r, g, b))
+	case (kind == kindRGB || kind == kind256) && m == ModeBasic:
+		r, g, b := c.rgb()
+		return nearestBasic(r, g, b)
+	}
+
+	return c
+}
+
+// basicPalette is RGB values of basic 8 colors and their bright variants, as xterm defines.
+var basicPalette = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels is the intensity of each step in the 6x6x6 color cube of the 256-color palette.
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// rgb returns RGB values of a single color.
+func (c Color) rgb() (uint8, uint8, uint8) {
+	value := int(c & valueMask)
+	switch c &^ valueMask {
+	case kindRGB:
+		return uint8(value >> 16), uint8(value >> 8), uint8(value)
+	case kind256:
+		switch {
+		case value < 16:
+			p := basicPalette[value]
+			return p[0], p[1], p[2]
+		case value < 232:
+			value -= 16
+			return cubeLevels[value/36], cubeLevels[value/6%6], cubeLevels[value%6]
+		default:
+			gray := uint8(8 + (value-232)*10)
+			return gray, gray, gray
+		}
+	default:
+		p := basicPalette[value]
+		return p[0], p[1], p[2]
+	}
+}
+
+// nearest256 returns the index of the nearest color in the 256-color palette,
+// choosing from the color cube and the grayscale ramp.
+func nearest256(r, g, b uint8) uint8 {
+	cubeIndex := func(v uint8) int {
+		nearest := 0
+		for i, level := range cubeLevels {
+			if abs(int(level)-int(v)) < abs(int(cubeLevels[nearest])-int(v)) {
+				nearest = i
+			}
+		}
+		return nearest
+	}
+
+	ri, gi, bi := cubeIndex(r), cubeIndex(g), cubeIndex(b)
+	cube := 16 + 36*ri + 6*gi + bi
+	cubeDistance := distance(r, g, b, cubeLevels[ri], cubeLevels[gi], cubeLevels[bi])
+
+	average := (int(r) + int(g) + int(b)) / 3
+	grayIndex := (average - 3) / 10
+	if grayIndex < 0 {
+		grayIndex = 0
+	}
+	if grayIndex > 23 {
+		grayIndex = 23
+	}
+	gray := uint8(8 + grayIndex*10)
+	if distance(r, g, b, gray, gray, gray) < cubeDistance {
+		return uint8(232 + grayIndex)
+	}
+
+	return uint8(cube)
+}
+
+// nearestBasic returns the nearest color in basic 8 colors.
+func nearestBasic(r, g, b uint8) Color {
+	nearest := 0
+	for i := 1; i < 8; i++ {
+		p, n := basicPalette[i], basicPalette[nearest]
+		if distance(r, g, b, p[0], p[1], p[2]) < distance(r, g, b, n[0], n[1], n[2]) {
+			nearest = i
+		}
+	}
+
+	return Black + Color(nearest)
+}
+
+// distance returns squared euclidean distance between two colors.
+func distance(r1, g1, b1, r2, g2, b2 uint8) int {
+	dr, dg, db := int(r1)-int(r2), int(g1)-int(g2), int(b1)-int(b2)
+	return dr*dr + dg*dg + db*db
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Apply returns val decorated by c.
+// If the terminal doesn't support c, the nearest supported color is used instead.
 func Apply(val string, c Color) string {
-	return fmt.Sprintf("%s[%dm%s%s[0m", escape, c.sequence(), val, escape)
+	seq := c.sequence(mode)
+	if seq == "" {
+		return val
+	}
+
+	return fmt.Sprintf("%s[%sm%s%s[0m", escape, seq, val, escape)
 }
@@ -1,6 +1,7 @@
 package color
 
 import (
+	"os"
 	"testing"
 )
 
@@ -11,3 +12,80 @@ func TestApply(t *testing.T) {
 		t.Fatalf("failed: %v", applied)
 	}
 }
+
+func TestApply_NoColor(t *testing.T) {
+	if applied := Apply("test", 0); applied != "test" {
+		t.Fatalf("failed: %v", applied)
+	}
+}
+
+func TestColor_sequence(t *testing.T) {
+	tests := []struct {
+		name     string
+		color    Color
+		mode     Mode
+		expected string
+	}{
+		{"basic", Red, ModeBasic, "31"},
+		{"basic in truecolor", Red, ModeTrueColor, "31"},
+		{"256", Color256(208), Mode256, "38;5;208"},
+		{"256 in truecolor", Color256(208), ModeTrueColor, "38;5;208"},
+		{"rgb", RGB(255, 135, 0), ModeTrueColor, "38;2;255;135;0"},
+		{"basic background", Background(Red), ModeBasic, "41"},
+		{"256 background", Background(Color256(208)), Mode256, "48;5;208"},
+		{"rgb background", Background(RGB(1, 2, 3)), ModeTrueColor, "48;2;1;2;3"},
+		{"foreground on background", White.On(Red), ModeBasic, "37;41"},
+		{"rgb foreground on 256 background", RGB(1, 2, 3).On(Color256(4)), ModeTrueColor, "38;2;1;2;3;48;5;4"},
+		{"bold", Bold, ModeBasic, "1"},
+		{"bold red", Red.With(Bold), ModeBasic, "1;31"},
+		{"every attribute", Bold.With(Faint).With(Italic).With(Underline).With(Reverse), ModeBasic, "1;2;3;4;7"},
+		{"underlined white on red", White.On(Red).With(Underline), ModeBasic, "4;37;41"},
+		{"With overrides foreground", Red.With(Bold).With(Blue), ModeBasic, "1;34"},
+		{"With keeps background", White.On(Red).With(Blue), ModeBasic, "34;41"},
+
+		{"rgb downgraded to 256", RGB(255, 135, 0), Mode256, "38;5;208"},
+		{"rgb gray downgraded to 256 grayscale", RGB(128, 128, 128), Mode256, "38;5;244"},
+		{"rgb downgraded to basic", RGB(250, 10, 10), ModeBasic, "31"},
+		{"256 downgraded to basic", Color256(21), ModeBasic, "34"},
+		{"256 bright downgraded to basic", Color256(10), ModeBasic, "32"},
+		{"background downgraded to basic", Background(RGB(0, 200, 200)), ModeBasic, "46"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.color.sequence(tt.mode); got != tt.expected {
+				t.Errorf("fail: got: %v, expected: %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDetectMode(t *testing.T) {
+	tests := []struct {
+		name      string
+		colorterm string
+		term      string
+		expected  Mode
+	}{
+		{"nothing", "", "", ModeBasic},
+		{"xterm", "", "xterm", ModeBasic},
+		{"xterm-256color", "", "xterm-256color", Mode256},
+		{"COLORTERM=truecolor", "truecolor", "xterm-256color", ModeTrueColor},
+		{"COLORTERM=24bit", "24bit", "xterm", ModeTrueColor},
+		{"xterm-direct", "", "xterm-direct", ModeTrueColor},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			defer os.Setenv("COLORTERM", os.Getenv("COLORTERM"))
+			defer os.Setenv("TERM", os.Getenv("TERM"))
+			os.Setenv("COLORTERM", tt.colorterm)
+			os.Setenv("TERM", tt.term)
+
+			if got := DetectMode(); got != tt.expected {
+				t.Errorf("fail: got: %v, expected: %v", got, tt.expected)
+			}
+		})
+	}
+}
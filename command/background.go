@@ -0,0 +1,71 @@
+package command
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// backgroundQueryTimeout is how long kubecolor waits for the terminal to answer its background color.
+const backgroundQueryTimeout = 100 * time.Millisecond
+
+// mocked in unit tests
+// detectDarkBackground returns if the terminal background is dark.
+// ok is false when the background color could not be detected.
+var detectDarkBackground = func() (dark bool, ok bool) {
+	if dark, ok := darkBackgroundFromColorFgBg(os.Getenv("COLORFGBG")); ok {
+		return dark, true
+	}
+
+	if !isOutputTerminal() {
+		return false, false
+	}
+
+	return darkBackgroundFromTerminal(queryTerminalBackground(backgroundQueryTimeout))
+}
+
+// darkBackgroundFromColorFgBg reads COLORFGBG environment variable which some terminals (e.g. rxvt, Konsole) set.
+// Its format is "fg;bg" or "fg;default;bg", where fg and bg are ANSI color numbers.
+func darkBackgroundFromColorFgBg(colorFgBg string) (bool, bool) {
+	if colorFgBg == "" {
+		return false, false
+	}
+
+	fields := strings.Split(colorFgBg, ";")
+	bg, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil || bg < 0 || bg > 15 {
+		return false, false
+	}
+
+	// 0-6 are black and dark colors, 8 is bright black (gray). Others are light colors.
+	return bg <= 6 || bg == 8, true
+}
+
+// oscBackgroundResponse matches the answer of OSC 11 query, e.g. "\x1b]11;rgb:ffff/ffff/ffff\x1b\\"
+var oscBackgroundResponse = regexp.MustCompile(`\x1b\]11;rgb:([0-9a-fA-F]{1,4})/([0-9a-fA-F]{1,4})/([0-9a-fA-F]{1,4})`)
+
+// darkBackgroundFromTerminal parses the terminal response to OSC 11 query,
+// then decides if it is dark based on its luminance.
+func darkBackgroundFromTerminal(response string) (bool, bool) {
+	matches := oscBackgroundResponse.FindStringSubmatch(response)
+	if matches == nil {
+		return false, false
+	}
+
+	rgb := make([]float64, 3)
+	for i, hex := range matches[1:] {
+		v, err := strconv.ParseUint(hex, 16, 16)
+		if err != nil {
+			return false, false
+		}
+
+		// each value has 1 to 4 hex digits, e.g. "f", "ff", "fff", "ffff"
+		max := float64(uint64(1)<<(4*len(hex)) - 1)
+		rgb[i] = float64(v) / max
+	}
+
+	luminance := 0.2126*rgb[0] + 0.7152*rgb[1] + 0.0722*rgb[2]
+	return luminance < 0.5, true
+}
@@ -0,0 +1,53 @@
+//go:build !windows
+
+package command
+
+import (
+	"os"
+	"regexp"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// deviceAttributesResponse matches the answer of DA1 query, e.g. "\x1b[?62;22c"
+var deviceAttributesResponse = regexp.MustCompile(`\x1b\[\?[0-9;]*c`)
+
+// queryTerminalBackground asks the terminal its background color using OSC 11 escape sequence,
+// then returns the raw response. It returns an empty string when the terminal didn't answer in the timeout.
+func queryTerminalBackground(timeout time.Duration) string {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return ""
+	}
+	defer tty.Close()
+
+	// if the tty doesn't support deadline, reading it might block forever
+	if err := tty.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return ""
+	}
+
+	state, err := term.MakeRaw(int(tty.Fd()))
+	if err != nil {
+		return ""
+	}
+	defer term.Restore(int(tty.Fd()), state)
+
+	// DA1 query is sent after OSC 11 query. Almost every terminal answers DA1 in order,
+	// so when DA1 response comes, there's no need to wait for OSC 11 response anymore.
+	if _, err := tty.WriteString("\x1b]11;?\x1b\\\x1b[c"); err != nil {
+		return ""
+	}
+
+	response := []byte{}
+	buf := make([]byte, 64)
+	for !deviceAttributesResponse.Match(response) {
+		n, err := tty.Read(buf)
+		response = append(response, buf[:n]...)
+		if err != nil {
+			break
+		}
+	}
+
+	return string(response)
+}
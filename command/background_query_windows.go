@@ -0,0 +1,8 @@
+package command
+
+import "time"
+
+// queryTerminalBackground is not supported on Windows because Windows console doesn't answer OSC 11 query.
+func queryTerminalBackground(timeout time.Duration) string {
+	return ""
+}
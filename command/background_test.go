@@ -0,0 +1,59 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hidetatz/kubecolor/testutil"
+)
+
+func Test_darkBackgroundFromColorFgBg(t *testing.T) {
+	tests := []struct {
+		colorFgBg    string
+		expectedDark bool
+		expectedOK   bool
+	}{
+		{"", false, false},
+		{"15;0", true, true},
+		{"0;15", false, true},
+		{"7;default;0", true, true},
+		{"0;default;7", false, true},
+		{"15;8", true, true},
+		{"0;default", false, false},
+		{"0;100", false, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.colorFgBg, func(t *testing.T) {
+			t.Parallel()
+			dark, ok := darkBackgroundFromColorFgBg(tt.colorFgBg)
+			testutil.MustEqual(t, tt.expectedDark, dark)
+			testutil.MustEqual(t, tt.expectedOK, ok)
+		})
+	}
+}
+
+func Test_darkBackgroundFromTerminal(t *testing.T) {
+	tests := []struct {
+		name         string
+		response     string
+		expectedDark bool
+		expectedOK   bool
+	}{
+		{"no response", "", false, false},
+		{"only DA1 response", "\x1b[?62;22c", false, false},
+		{"black, terminated by ST", "\x1b]11;rgb:0000/0000/0000\x1b\\\x1b[?62;22c", true, true},
+		{"white, terminated by BEL", "\x1b]11;rgb:ffff/ffff/ffff\x07\x1b[?62;22c", false, true},
+		{"solarized dark", "\x1b]11;rgb:0000/2b2b/3636\x1b\\", true, true},
+		{"solarized light", "\x1b]11;rgb:fdfd/f6f6/e3e3\x1b\\", false, true},
+		{"2 digits", "\x1b]11;rgb:ee/ee/ee\x1b\\", false, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dark, ok := darkBackgroundFromTerminal(tt.response)
+			testutil.MustEqual(t, tt.expectedDark, dark)
+			testutil.MustEqual(t, tt.expectedOK, ok)
+		})
+	}
+}
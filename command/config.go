@@ -1,6 +1,18 @@
 package command
 
-import "os"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hidetatz/kubecolor/kubectl"
+	"github.com/hidetatz/kubecolor/printer"
+	"gopkg.in/yaml.v3"
+)
 
 type KubecolorConfig struct {
 	Plain                bool
@@ -8,36 +20,401 @@ type KubecolorConfig struct {
 	ForceColor           bool
 	ShowKubecolorVersion bool
 	KubectlCmd           string
+
+	// Theme is colors used to print. It is chosen by DarkBackground unless a theme is specified by name.
+	Theme *printer.Theme
+
+	// DetectBackground is true when the background is not configured and should be detected from the terminal.
+	// The detection is done by ResolveBackground only when the output is colorized,
+	// because it queries the terminal, which can interfere with interactive commands (e.g. kubectl exec -it).
+	DetectBackground bool
+
+	// Subcommands holds subcommands which are explicitly enabled or disabled
+	// in the config file. Subcommands not in the map follow the default behavior.
+	Subcommands map[kubectl.Subcommand]bool
+
+	// ReformatJSONLogs makes json logs in kubectl logs printed as "time level msg key=value...".
+	ReformatJSONLogs bool
+
+	// RestartsThresholds, CPUThresholds and MemoryThresholds is thresholds to colorize
+	// RESTARTS in kubectl get, CPU% and MEMORY% in kubectl top.
+	// They are zero when not configured, then the printer uses its default.
+	RestartsThresholds printer.Thresholds
+	CPUThresholds      printer.Thresholds
+	MemoryThresholds   printer.Thresholds
+
+	// Plugins is printer names for kubectl plugins, keyed by the plugin name, e.g. "neat" or "cnpg status".
+	// A plugin is colorized only when it's configured here, because it can be interactive.
+	// PluginPlain means the plugin is not colorized, which is the default.
+	Plugins map[string]string
+}
+
+// PluginPlain is a printer name for a plugin in the config file, which makes the plugin not colorized.
+// It is the same as not configuring the plugin.
+const PluginPlain = "plain"
+
+// configFile is the format of kubecolor config file. e.g.
+//
+//	kubectl: kubectl.1.19
+//	background: light
+//	theme: solarized
+//	forceColors: true
+//	reformatJsonLogs: true
+//	subcommands:
+//	  logs: false
+//	  exec: true
+//	thresholds:
+//	  restarts:
+//	    warning: 1
+//	    error: 10
+//	  cpu:
+//	    warning: 70
+//	    error: 90
+//	plugins:
+//	  neat: yaml
+//	  tree: auto
+type configFile struct {
+	Kubectl          string            `yaml:"kubectl"`
+	Background       string            `yaml:"background"`
+	Theme            string            `yaml:"theme"`
+	ForceColors      bool              `yaml:"forceColors"`
+	ReformatJSONLogs bool              `yaml:"reformatJsonLogs"`
+	Subcommands      map[string]bool   `yaml:"subcommands"`
+	Thresholds       thresholdsFile    `yaml:"thresholds"`
+	Plugins          map[string]string `yaml:"plugins"`
+}
+
+// thresholdsFile is thresholds to colorize numeric values in the config file.
+type thresholdsFile struct {
+	Restarts *thresholdFile `yaml:"restarts"`
+	CPU      *thresholdFile `yaml:"cpu"`
+	Memory   *thresholdFile `yaml:"memory"`
+}
+
+// thresholdFile is a threshold in the config file. Either of warning or error can be omitted.
+type thresholdFile struct {
+	Warning *int `yaml:"warning"`
+	Error   *int `yaml:"error"`
 }
 
-func ResolveConfig(args []string) ([]string, *KubecolorConfig) {
+// resolve returns thresholds filling omitted values with defaults.
+// It returns zero when the threshold is not configured at all.
+func (t *thresholdFile) resolve(defaults printer.Thresholds) printer.Thresholds {
+	if t == nil {
+		return printer.Thresholds{}
+	}
+
+	ret := defaults
+	if t.Warning != nil {
+		ret.Warning = *t.Warning
+	}
+	if t.Error != nil {
+		ret.Error = *t.Error
+	}
+
+	return ret
+}
+
+// mocked in unit tests
+var defaultConfigPath = func() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(home, ".kube", "color.yaml")
+}
+
+// sources of a config value in the order of precedence, to compare values which affect each other
+const (
+	sourceNone = iota
+	sourceFile
+	sourceEnv
+	sourceFlag
+)
+
+// ResolveConfig reads kubecolor specific flags from args, then returns args without them and the config.
+// Each config value is decided in the order of: command line flag > environment variable > config file > default.
+func ResolveConfig(args []string) ([]string, *KubecolorConfig, error) {
 	args, plainFlagFound := findAndRemoveBoolFlagIfExists(args, "--plain")
 	args, lightBackgroundFlagFound := findAndRemoveBoolFlagIfExists(args, "--light-background")
 	args, forceColorFlagFound := findAndRemoveBoolFlagIfExists(args, "--force-colors")
 	args, kubecolorVersionFlagFound := findAndRemoveBoolFlagIfExists(args, "--kubecolor-version")
+	args, themeName, err := findAndRemoveStringFlagIfExists(args, "--kubecolor-theme")
+	if err != nil {
+		return args, nil, err
+	}
 
-	darkBackground := !lightBackgroundFlagFound
+	file, err := loadConfigFile()
+	if err != nil {
+		return args, nil, err
+	}
 
 	kubectlCmd := "kubectl"
+	if file.Kubectl != "" {
+		kubectlCmd = file.Kubectl
+	}
 	if kc := os.Getenv("KUBECTL_COMMAND"); kc != "" {
 		kubectlCmd = kc
 	}
 
+	themeSource := sourceNone
+	switch {
+	case themeName != "":
+		themeSource = sourceFlag
+	case os.Getenv("KUBECOLOR_THEME") != "":
+		themeName, themeSource = os.Getenv("KUBECOLOR_THEME"), sourceEnv
+	case file.Theme != "":
+		themeName, themeSource = file.Theme, sourceFile
+	}
+
+	plain := resolvePlain(plainFlagFound, forceColorFlagFound)
+	forceColor := resolveForceColor(forceColorFlagFound, file.ForceColors)
+
+	reformatJSONLogs := file.ReformatJSONLogs
+	if v, ok := boolEnv("KUBECOLOR_REFORMAT_JSON_LOGS"); ok {
+		reformatJSONLogs = v
+	}
+
+	lightBackgroundEnv, lightBackgroundEnvFound := boolEnv("KUBECOLOR_LIGHT_BACKGROUND")
+
+	darkBackground := true
+	backgroundSource := sourceNone
+	switch {
+	case lightBackgroundFlagFound:
+		darkBackground, backgroundSource = false, sourceFlag
+	case lightBackgroundEnvFound:
+		darkBackground, backgroundSource = !lightBackgroundEnv, sourceEnv
+	case file.Background != "":
+		darkBackground, backgroundSource = file.Background == "dark", sourceFile
+	}
+
+	theme := printer.ThemeByBackground(darkBackground)
+	if themeName != "" {
+		t, ok := printer.ThemeByName(themeName)
+		if !ok {
+			return args, nil, fmt.Errorf("unknown theme %q, available themes are: %s", themeName, strings.Join(printer.ThemeNames(), ", "))
+		}
+
+		// the theme and the background share the precedence, and the theme wins when they are given in the same way.
+		// the background follows the theme so that they don't disagree, e.g. the light theme on dark background
+		if themeSource >= backgroundSource {
+			theme = t
+			if dark, ok := printer.ThemeBackground(themeName); ok {
+				darkBackground = dark
+			}
+		}
+	}
+
+	// the background is detected later in ResolveBackground only when it is actually needed
+	detectBackground := backgroundSource == sourceNone && themeSource == sourceNone && !plain && !kubecolorVersionFlagFound
+
+	subcommands, err := toSubcommandToggles(file.Subcommands)
+	if err != nil {
+		return args, nil, err
+	}
+
 	return args, &KubecolorConfig{
-		Plain:                plainFlagFound,
+		Plain:                plain,
 		DarkBackground:       darkBackground,
-		ForceColor:           forceColorFlagFound,
+		ForceColor:           forceColor,
 		ShowKubecolorVersion: kubecolorVersionFlagFound,
 		KubectlCmd:           kubectlCmd,
+		Theme:                theme,
+		DetectBackground:     detectBackground,
+		Subcommands:          subcommands,
+		ReformatJSONLogs:     reformatJSONLogs,
+		RestartsThresholds:   file.Thresholds.Restarts.resolve(printer.DefaultRestartsThresholds),
+		CPUThresholds:        file.Thresholds.CPU.resolve(printer.DefaultCPUThresholds),
+		MemoryThresholds:     file.Thresholds.Memory.resolve(printer.DefaultMemoryThresholds),
+		Plugins:              file.Plugins,
+	}, nil
+}
+
+// ResolveBackground detects the terminal background if needed, then chooses the theme for it.
+// It should be called only when the output is colorized.
+func (c *KubecolorConfig) ResolveBackground() {
+	if !c.DetectBackground {
+		return
+	}
+	c.DetectBackground = false
+
+	if dark, ok := detectDarkBackground(); ok {
+		c.DarkBackground = dark
+		c.Theme = printer.ThemeByBackground(dark)
 	}
 }
 
+// resolvePlain decides if kubecolor should not colorize the output at all.
+// Other than --plain and KUBECOLOR_PLAIN, it respects NO_COLOR (https://no-color.org/) and TERM=dumb.
+// Because explicitly asking colors is prior to these conventions, --force-colors and KUBECOLOR_FORCE_COLORS=true disable them.
+func resolvePlain(plainFlag, forceColorFlag bool) bool {
+	if plainFlag || forceColorFlag {
+		return plainFlag
+	}
+
+	if plain, ok := boolEnv("KUBECOLOR_PLAIN"); ok {
+		return plain
+	}
+
+	if forceColor, ok := boolEnv("KUBECOLOR_FORCE_COLORS"); ok && forceColor {
+		return false
+	}
+
+	return os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb"
+}
+
+// resolveForceColor decides if kubecolor should colorize the output even if it is not a terminal.
+func resolveForceColor(forceColorFlag, forceColorFile bool) bool {
+	if forceColorFlag {
+		return true
+	}
+
+	if forceColor, ok := boolEnv("KUBECOLOR_FORCE_COLORS"); ok {
+		return forceColor
+	}
+
+	return forceColorFile
+}
+
+// boolEnv returns the value of the environment variable as bool.
+// ok is false when the variable is not set or its value is not a bool (e.g. "true", "false", "1", "0").
+func boolEnv(key string) (value bool, ok bool) {
+	v, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return false, false
+	}
+
+	return v, true
+}
+
+// loadConfigFile reads the config file.
+// The file specified by KUBECOLOR_CONFIG must exist, but the default one (~/.kube/color.yaml) is optional.
+// When no file is found, it returns an empty config.
+func loadConfigFile() (*configFile, error) {
+	path := os.Getenv("KUBECOLOR_CONFIG")
+	explicit := path != ""
+	if !explicit {
+		path = defaultConfigPath()
+	}
+
+	file := &configFile{}
+	if path == "" {
+		return file, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			return file, nil
+		}
+		return nil, fmt.Errorf("open config file: %w", err)
+	}
+	defer f.Close()
+
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(file); err != nil {
+		// an empty file is not an error
+		if errors.Is(err, io.EOF) {
+			return file, nil
+		}
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	switch file.Background {
+	case "", "dark", "light":
+	default:
+		return nil, fmt.Errorf("parse config file %s: background must be dark or light, got %q", path, file.Background)
+	}
+
+	thresholds := []struct {
+		name     string
+		file     *thresholdFile
+		defaults printer.Thresholds
+	}{
+		{"restarts", file.Thresholds.Restarts, printer.DefaultRestartsThresholds},
+		{"cpu", file.Thresholds.CPU, printer.DefaultCPUThresholds},
+		{"memory", file.Thresholds.Memory, printer.DefaultMemoryThresholds},
+	}
+	for _, t := range thresholds {
+		if r := t.file.resolve(t.defaults); r.Warning > r.Error {
+			return nil, fmt.Errorf("parse config file %s: thresholds.%s.warning must not be greater than error", path, t.name)
+		}
+	}
+
+	printerNames := append(printer.PrinterNames(), PluginPlain)
+	for name, p := range file.Plugins {
+		if !containsString(printerNames, p) {
+			return nil, fmt.Errorf("parse config file %s: unknown printer %q for plugin %s, available printers are: %s",
+				path, p, name, strings.Join(printerNames, ", "))
+		}
+	}
+
+	return file, nil
+}
+
+// toSubcommandToggles converts subcommand names in the config file into kubectl.Subcommand.
+func toSubcommandToggles(m map[string]bool) (map[kubectl.Subcommand]bool, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+
+	toggles := make(map[kubectl.Subcommand]bool, len(m))
+	for name, enabled := range m {
+		sc, ok := kubectl.InspectSubcommand(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown subcommand in config file: %s", name)
+		}
+		toggles[sc] = enabled
+	}
+
+	return toggles, nil
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
 func findAndRemoveBoolFlagIfExists(args []string, key string) ([]string, bool) {
 	for i, arg := range args {
 		if arg == key {
-			return append(args[:i], args[i+1:]...), true
+			return removeArgs(args, i, 1), true
 		}
 	}
 
 	return args, false
 }
+
+// findAndRemoveStringFlagIfExists finds a flag which has a value in both forms of "--key=value" and "--key value".
+// It returns args without the flag and the value. The value is empty if the flag is not found.
+// It returns an error if the flag is found without value.
+func findAndRemoveStringFlagIfExists(args []string, key string) ([]string, string, error) {
+	for i, arg := range args {
+		if strings.HasPrefix(arg, key+"=") {
+			return removeArgs(args, i, 1), strings.TrimPrefix(arg, key+"="), nil
+		}
+
+		if arg == key {
+			if i+1 >= len(args) {
+				return args, "", fmt.Errorf("flag needs an argument: %s", key)
+			}
+			return removeArgs(args, i, 2), args[i+1], nil
+		}
+	}
+
+	return args, "", nil
+}
+
+// removeArgs returns a new slice of args without n args from i. args is not modified.
+func removeArgs(args []string, i, n int) []string {
+	ret := make([]string, 0, len(args)-n)
+	ret = append(ret, args[:i]...)
+	return append(ret, args[i+n:]...)
+}
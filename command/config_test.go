@@ -2,8 +2,11 @@ package command
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/hidetatz/kubecolor/kubectl"
+	"github.com/hidetatz/kubecolor/printer"
 	"github.com/hidetatz/kubecolor/testutil"
 )
 
@@ -12,6 +15,9 @@ func Test_ResolveConfig(t *testing.T) {
 		name           string
 		args           []string
 		kubectlCommand string
+		configFile     string
+		detectedDark   func() (bool, bool)
+		env            map[string]string
 		expectedArgs   []string
 		expectedConf   *KubecolorConfig
 	}{
@@ -24,6 +30,7 @@ func Test_ResolveConfig(t *testing.T) {
 				DarkBackground: true,
 				ForceColor:     false,
 				KubectlCmd:     "kubectl",
+				Theme:          printer.DarkTheme(),
 			},
 		},
 		{
@@ -35,6 +42,7 @@ func Test_ResolveConfig(t *testing.T) {
 				DarkBackground: false,
 				ForceColor:     true,
 				KubectlCmd:     "kubectl",
+				Theme:          printer.LightTheme(),
 			},
 		},
 		{
@@ -47,20 +55,606 @@ func Test_ResolveConfig(t *testing.T) {
 				DarkBackground: true,
 				ForceColor:     false,
 				KubectlCmd:     "kubectl.1.19",
+				Theme:          printer.DarkTheme(),
+			},
+		},
+		{
+			name: "config file is used as default",
+			args: []string{"get", "pods"},
+			configFile: testutil.NewHereDoc(`
+				kubectl: kubectl.1.20
+				background: light
+				forceColors: true
+				subcommands:
+				  logs: false
+				  exec: true
+			`),
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: false,
+				ForceColor:     true,
+				KubectlCmd:     "kubectl.1.20",
+				Theme:          printer.LightTheme(),
+				Subcommands: map[kubectl.Subcommand]bool{
+					kubectl.Logs: false,
+					kubectl.Exec: true,
+				},
+			},
+		},
+		{
+			name:           "KUBECTL_COMMAND is prior to config file",
+			args:           []string{"get", "pods"},
+			kubectlCommand: "kubectl.1.19",
+			configFile: testutil.NewHereDoc(`
+				kubectl: kubectl.1.20
+			`),
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: true,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl.1.19",
+				Theme:          printer.DarkTheme(),
+			},
+		},
+		{
+			name: "flag is prior to config file",
+			args: []string{"get", "pods", "--light-background"},
+			configFile: testutil.NewHereDoc(`
+				background: dark
+			`),
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: false,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.LightTheme(),
+			},
+		},
+		{
+			name:         "config file without any values",
+			args:         []string{"get", "pods"},
+			configFile:   "# nothing is configured\n",
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: true,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.DarkTheme(),
+			},
+		},
+		{
+			name:         "theme flag",
+			args:         []string{"get", "--kubecolor-theme=solarized", "pods"},
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: true,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.SolarizedTheme(),
+			},
+		},
+		{
+			name:         "theme flag with separated value",
+			args:         []string{"get", "pods", "--kubecolor-theme", "high-contrast"},
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: true,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.HighContrastTheme(),
+			},
+		},
+		{
+			name: "theme in config file is prior to background",
+			args: []string{"get", "pods"},
+			configFile: testutil.NewHereDoc(`
+				background: light
+				theme: colorblind-safe
+			`),
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: false,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.ColorblindSafeTheme(),
+			},
+		},
+		{
+			name:         "background follows the theme",
+			args:         []string{"get", "pods", "--light-background", "--kubecolor-theme=high-contrast"},
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: true,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.HighContrastTheme(),
+			},
+		},
+		{
+			name: "theme flag is prior to config file",
+			args: []string{"get", "pods", "--kubecolor-theme=light"},
+			configFile: testutil.NewHereDoc(`
+				theme: solarized
+			`),
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: false,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.LightTheme(),
+			},
+		},
+		{
+			name: "background flag is prior to theme in config file",
+			args: []string{"get", "pods", "--light-background"},
+			configFile: testutil.NewHereDoc(`
+				theme: dark
+			`),
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: false,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.LightTheme(),
+			},
+		},
+		{
+			name: "background env is prior to theme in config file",
+			args: []string{"get", "pods"},
+			configFile: testutil.NewHereDoc(`
+				theme: light
+			`),
+			env:          map[string]string{"KUBECOLOR_LIGHT_BACKGROUND": "false"},
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: true,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.DarkTheme(),
+			},
+		},
+		{
+			name: "theme env is prior to background in config file",
+			args: []string{"get", "pods"},
+			configFile: testutil.NewHereDoc(`
+				background: dark
+			`),
+			env:          map[string]string{"KUBECOLOR_THEME": "light"},
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: false,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.LightTheme(),
+			},
+		},
+		{
+			name:         "light background is detected",
+			args:         []string{"get", "pods"},
+			detectedDark: func() (bool, bool) { return false, true },
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: false,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.LightTheme(),
+			},
+		},
+		{
+			name: "background in config file is prior to detection",
+			args: []string{"get", "pods"},
+			configFile: testutil.NewHereDoc(`
+				background: dark
+			`),
+			detectedDark: func() (bool, bool) { return false, true },
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: true,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.DarkTheme(),
+			},
+		},
+		{
+			name:         "flag is prior to detection",
+			args:         []string{"get", "pods", "--light-background"},
+			detectedDark: func() (bool, bool) { return true, true },
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: false,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.LightTheme(),
+			},
+		},
+		{
+			name:         "NO_COLOR",
+			args:         []string{"get", "pods"},
+			env:          map[string]string{"NO_COLOR": "1"},
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          true,
+				DarkBackground: true,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.DarkTheme(),
+			},
+		},
+		{
+			name:         "TERM=dumb",
+			args:         []string{"get", "pods"},
+			env:          map[string]string{"TERM": "dumb"},
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          true,
+				DarkBackground: true,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.DarkTheme(),
+			},
+		},
+		{
+			name:         "--force-colors is prior to NO_COLOR",
+			args:         []string{"get", "pods", "--force-colors"},
+			env:          map[string]string{"NO_COLOR": "1"},
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: true,
+				ForceColor:     true,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.DarkTheme(),
+			},
+		},
+		{
+			name:         "KUBECOLOR_FORCE_COLORS is prior to NO_COLOR",
+			args:         []string{"get", "pods"},
+			env:          map[string]string{"NO_COLOR": "1", "KUBECOLOR_FORCE_COLORS": "true"},
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: true,
+				ForceColor:     true,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.DarkTheme(),
+			},
+		},
+		{
+			name:         "KUBECOLOR_PLAIN",
+			args:         []string{"get", "pods"},
+			env:          map[string]string{"KUBECOLOR_PLAIN": "true"},
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          true,
+				DarkBackground: true,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.DarkTheme(),
+			},
+		},
+		{
+			name:         "KUBECOLOR_PLAIN=false is prior to NO_COLOR",
+			args:         []string{"get", "pods"},
+			env:          map[string]string{"KUBECOLOR_PLAIN": "false", "NO_COLOR": "1"},
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: true,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.DarkTheme(),
+			},
+		},
+		{
+			name:         "--force-colors is prior to KUBECOLOR_PLAIN",
+			args:         []string{"get", "pods", "--force-colors"},
+			env:          map[string]string{"KUBECOLOR_PLAIN": "true"},
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: true,
+				ForceColor:     true,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.DarkTheme(),
+			},
+		},
+		{
+			name: "KUBECOLOR_FORCE_COLORS=false is prior to config file",
+			args: []string{"get", "pods"},
+			configFile: testutil.NewHereDoc(`
+				forceColors: true
+			`),
+			env:          map[string]string{"KUBECOLOR_FORCE_COLORS": "false"},
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: true,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.DarkTheme(),
+			},
+		},
+		{
+			name: "KUBECOLOR_LIGHT_BACKGROUND is prior to config file",
+			args: []string{"get", "pods"},
+			configFile: testutil.NewHereDoc(`
+				background: dark
+			`),
+			env:          map[string]string{"KUBECOLOR_LIGHT_BACKGROUND": "true"},
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: false,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.LightTheme(),
+			},
+		},
+		{
+			name:         "KUBECOLOR_LIGHT_BACKGROUND=false is prior to detection",
+			args:         []string{"get", "pods"},
+			detectedDark: func() (bool, bool) { return false, true },
+			env:          map[string]string{"KUBECOLOR_LIGHT_BACKGROUND": "false"},
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: true,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.DarkTheme(),
+			},
+		},
+		{
+			name: "reformatJsonLogs in config file",
+			args: []string{"logs", "nginx"},
+			configFile: testutil.NewHereDoc(`
+				reformatJsonLogs: true
+			`),
+			expectedArgs: []string{"logs", "nginx"},
+			expectedConf: &KubecolorConfig{
+				Plain:            false,
+				DarkBackground:   true,
+				ForceColor:       false,
+				KubectlCmd:       "kubectl",
+				Theme:            printer.DarkTheme(),
+				ReformatJSONLogs: true,
+			},
+		},
+		{
+			name: "KUBECOLOR_REFORMAT_JSON_LOGS is prior to config file",
+			args: []string{"logs", "nginx"},
+			configFile: testutil.NewHereDoc(`
+				reformatJsonLogs: true
+			`),
+			env:          map[string]string{"KUBECOLOR_REFORMAT_JSON_LOGS": "false"},
+			expectedArgs: []string{"logs", "nginx"},
+			expectedConf: &KubecolorConfig{
+				Plain:            false,
+				DarkBackground:   true,
+				ForceColor:       false,
+				KubectlCmd:       "kubectl",
+				Theme:            printer.DarkTheme(),
+				ReformatJSONLogs: false,
+			},
+		},
+		{
+			name: "thresholds in config file",
+			args: []string{"get", "pods"},
+			configFile: testutil.NewHereDoc(`
+				thresholds:
+				  restarts:
+				    error: 5
+				  memory:
+				    warning: 50
+				    error: 80
+			`),
+			expectedArgs: []string{"get", "pods"},
+			expectedConf: &KubecolorConfig{
+				Plain:              false,
+				DarkBackground:     true,
+				ForceColor:         false,
+				KubectlCmd:         "kubectl",
+				Theme:              printer.DarkTheme(),
+				RestartsThresholds: printer.Thresholds{Warning: 1, Error: 5},
+				MemoryThresholds:   printer.Thresholds{Warning: 50, Error: 80},
+			},
+		},
+		{
+			name: "plugins in config file",
+			args: []string{"neat", "get", "pod", "nginx"},
+			configFile: testutil.NewHereDoc(`
+				plugins:
+				  neat: yaml
+				  cnpg status: describe
+				  ctx: plain
+			`),
+			expectedArgs: []string{"neat", "get", "pod", "nginx"},
+			expectedConf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: true,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Theme:          printer.DarkTheme(),
+				Plugins:        map[string]string{"neat": "yaml", "cnpg status": "describe", "ctx": "plain"},
 			},
 		},
 	}
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range envKeys {
+				t.Setenv(key, tt.env[key])
+			}
+
 			if tt.kubectlCommand != "" {
-				os.Setenv("KUBECTL_COMMAND", tt.kubectlCommand)
-				defer os.Unsetenv("KUBECTL_COMMAND")
+				t.Setenv("KUBECTL_COMMAND", tt.kubectlCommand)
 			}
 
-			args, conf := ResolveConfig(tt.args)
+			detectDarkBackground = func() (bool, bool) { return false, false }
+			if tt.detectedDark != nil {
+				detectDarkBackground = tt.detectedDark
+			}
+
+			defaultConfigPath = func() string { return filepath.Join(t.TempDir(), "not-found.yaml") }
+			if tt.configFile != "" {
+				defaultConfigPath = func() string { return writeConfigFile(t, tt.configFile) }
+			}
+
+			args, conf, err := ResolveConfig(tt.args)
+			testutil.MustEqual(t, nil, err)
+			conf.ResolveBackground()
 			testutil.MustEqual(t, tt.expectedArgs, args)
 			testutil.MustEqual(t, tt.expectedConf, conf)
 		})
 	}
 }
+
+func Test_ResolveConfig_KUBECOLOR_CONFIG(t *testing.T) {
+	clearEnv(t)
+	detectDarkBackground = func() (bool, bool) { return false, false }
+	defaultConfigPath = func() string { return writeConfigFile(t, "background: dark") }
+
+	t.Setenv("KUBECOLOR_CONFIG", writeConfigFile(t, "background: light"))
+
+	_, conf, err := ResolveConfig([]string{"get", "pods"})
+	testutil.MustEqual(t, nil, err)
+	testutil.MustEqual(t, false, conf.DarkBackground)
+}
+
+func Test_ResolveConfig_InvalidConfigFile(t *testing.T) {
+	tests := []struct {
+		name       string
+		configFile string
+	}{
+		{"unknown field", "unknown: true"},
+		{"invalid background", "background: pink"},
+		{"unknown subcommand", "subcommands:\n  foo: true"},
+		{"unknown theme", "theme: pink"},
+		{"broken yaml", "background: [dark"},
+		{"unknown threshold", "thresholds:\n  foo:\n    warning: 1"},
+		{"warning threshold greater than error", "thresholds:\n  restarts:\n    warning: 20"},
+		{"cpu warning threshold greater than error", "thresholds:\n  cpu:\n    warning: 95"},
+		{"unknown plugin printer", "plugins:\n  neat: pink"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			defaultConfigPath = func() string { return writeConfigFile(t, tt.configFile) }
+
+			if _, _, err := ResolveConfig([]string{"get", "pods"}); err == nil {
+				t.Errorf("error must be returned")
+			}
+		})
+	}
+}
+
+func Test_ResolveConfig_KUBECOLOR_THEME(t *testing.T) {
+	clearEnv(t)
+	defaultConfigPath = func() string { return writeConfigFile(t, "theme: light") }
+
+	t.Setenv("KUBECOLOR_THEME", "solarized")
+
+	_, conf, err := ResolveConfig([]string{"get", "pods"})
+	testutil.MustEqual(t, nil, err)
+	testutil.MustEqual(t, printer.SolarizedTheme(), conf.Theme)
+}
+
+func Test_ResolveConfig_KUBECOLOR_CONFIG_NotFound(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("KUBECOLOR_CONFIG", filepath.Join(t.TempDir(), "not-found.yaml"))
+
+	if _, _, err := ResolveConfig([]string{"get", "pods"}); err == nil {
+		t.Errorf("error must be returned")
+	}
+}
+
+// envKeys are environment variables which affect ResolveConfig.
+var envKeys = []string{
+	"NO_COLOR",
+	"TERM",
+	"KUBECOLOR_PLAIN",
+	"KUBECOLOR_FORCE_COLORS",
+	"KUBECOLOR_LIGHT_BACKGROUND",
+	"KUBECOLOR_THEME",
+	"KUBECOLOR_REFORMAT_JSON_LOGS",
+	"KUBECOLOR_CONFIG",
+	"KUBECTL_COMMAND",
+}
+
+// clearEnv clears envKeys during the test not to be affected by the environment of the developer.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range envKeys {
+		t.Setenv(key, "")
+	}
+}
+
+// writeConfigFile writes content into a temporary file then returns its path.
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "color.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func Test_ResolveConfig_ThemeFlagWithoutValue(t *testing.T) {
+	clearEnv(t)
+	defaultConfigPath = func() string { return filepath.Join(t.TempDir(), "not-found.yaml") }
+
+	if _, _, err := ResolveConfig([]string{"get", "pods", "--kubecolor-theme"}); err == nil {
+		t.Errorf("error must be returned")
+	}
+}
+
+func Test_findAndRemoveStringFlagIfExists(t *testing.T) {
+	args := []string{"get", "--kubecolor-theme", "light", "pods", "-o", "wide"}
+	got, value, err := findAndRemoveStringFlagIfExists(args, "--kubecolor-theme")
+	testutil.MustEqual(t, nil, err)
+	testutil.MustEqual(t, "light", value)
+	testutil.MustEqual(t, []string{"get", "pods", "-o", "wide"}, got)
+
+	// the given args must not be modified
+	testutil.MustEqual(t, []string{"get", "--kubecolor-theme", "light", "pods", "-o", "wide"}, args)
+}
+
+func Test_ResolveConfig_DetectBackgroundLazily(t *testing.T) {
+	clearEnv(t)
+	defaultConfigPath = func() string { return filepath.Join(t.TempDir(), "not-found.yaml") }
+	detected := false
+	detectDarkBackground = func() (bool, bool) {
+		detected = true
+		return false, true
+	}
+
+	_, conf, err := ResolveConfig([]string{"exec", "-it", "nginx", "--", "sh"})
+	testutil.MustEqual(t, nil, err)
+	testutil.MustEqual(t, false, detected)
+	testutil.MustEqual(t, true, conf.DetectBackground)
+	testutil.MustEqual(t, printer.DarkTheme(), conf.Theme)
+
+	conf.ResolveBackground()
+	testutil.MustEqual(t, true, detected)
+	testutil.MustEqual(t, false, conf.DetectBackground)
+	testutil.MustEqual(t, false, conf.DarkBackground)
+	testutil.MustEqual(t, printer.LightTheme(), conf.Theme)
+}
@@ -26,42 +26,74 @@ type Printers struct {
 }
 
 // This is defined here to be replaced in test
-var getPrinters = func(subcommandInfo *kubectl.SubcommandInfo, darkBackground bool) *Printers {
+var getPrinters = func(subcommandInfo *kubectl.SubcommandInfo, config *KubecolorConfig, registry *printer.Registry) *Printers {
+	theme := config.Theme
 	return &Printers{
 		FullColoredPrinter: &printer.KubectlOutputColoredPrinter{
-			SubcommandInfo: subcommandInfo,
-			DarkBackground: darkBackground,
-			Recursive:      subcommandInfo.Recursive,
+			SubcommandInfo:     subcommandInfo,
+			Theme:              theme,
+			Recursive:          subcommandInfo.Recursive,
+			ReformatJSONLogs:   config.ReformatJSONLogs,
+			RestartsThresholds: config.RestartsThresholds,
+			CPUThresholds:      config.CPUThresholds,
+			MemoryThresholds:   config.MemoryThresholds,
+			Registry:           registry,
 		},
 		ErrorPrinter: &printer.WithFuncPrinter{
 			Fn: func(line string) color.Color {
 				if strings.HasPrefix(strings.ToLower(line), "error") {
-					return color.Red
+					return theme.Error
 				}
 
-				return color.Yellow
+				return theme.Warning
 			},
 		},
 	}
 }
 
+// newRegistry returns rules to choose printers, including printers for plugins in the config file.
+func newRegistry(config *KubecolorConfig) *printer.Registry {
+	registry := printer.DefaultRegistry()
+	for name, p := range config.Plugins {
+		// PluginPlain is not a printer, so the plugin is left to the default rule which doesn't colorize it
+		if rule, ok := printer.NewPluginRule(name, p); ok {
+			registry.Register(rule)
+		}
+	}
+
+	return registry
+}
+
 func Run(args []string, version string) error {
-	args, config := ResolveConfig(args)
-	shouldColorize, subcommandInfo := ResolveSubcommand(args, config)
+	args, config, err := ResolveConfig(args)
+	if err != nil {
+		return fmt.Errorf("kubecolor: %w", err)
+	}
+
+	registry := newRegistry(config)
+	shouldColorize, subcommandInfo := ResolveSubcommand(args, config, registry)
 
 	if config.ShowKubecolorVersion {
 		fmt.Fprintf(os.Stdout, "%s\n", version)
 		return nil
 	}
 
+	if shouldColorize {
+		config.ResolveBackground()
+	}
+
 	cmd := exec.Command(config.KubectlCmd, args...)
 	cmd.Stdin = os.Stdin
 
 	// when should not colorize, just run command and return
-	// TODO: right now, krew is unsupported by kubecolor but it should be.
 	if !shouldColorize {
 		cmd.Stdout = Stdout
 		cmd.Stderr = Stderr
+		if subcommandInfo.IsKrew {
+			// a plugin can be interactive (e.g. fzf in kubectl ctx), so it's given the terminal as is
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+		}
 		if err := cmd.Start(); err != nil {
 			return err
 		}
@@ -93,7 +125,7 @@ func Run(args []string, version string) error {
 		return err
 	}
 
-	printers := getPrinters(subcommandInfo, config.DarkBackground)
+	printers := getPrinters(subcommandInfo, config, registry)
 
 	wg := &sync.WaitGroup{}
 
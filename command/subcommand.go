@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/hidetatz/kubecolor/kubectl"
+	"github.com/hidetatz/kubecolor/printer"
 	"github.com/mattn/go-isatty"
 )
 
@@ -13,7 +14,9 @@ var isOutputTerminal = func() bool {
 	return isatty.IsTerminal(os.Stdout.Fd()) || isatty.IsCygwinTerminal(os.Stdout.Fd())
 }
 
-func ResolveSubcommand(args []string, config *KubecolorConfig) (bool, *kubectl.SubcommandInfo) {
+// ResolveSubcommand finds the subcommand in args, then returns if its output should be colorized.
+// registry decides if the subcommand is colorized by default. See newRegistry.
+func ResolveSubcommand(args []string, config *KubecolorConfig, registry *printer.Registry) (bool, *kubectl.SubcommandInfo) {
 	// subcommandFound becomes false when subcommand is not found; e.g. "kubecolor --help"
 	subcommandInfo, subcommandFound := kubectl.InspectSubcommandInfo(args)
 
@@ -38,6 +41,16 @@ func ResolveSubcommand(args []string, config *KubecolorConfig) (bool, *kubectl.S
 		return true, subcommandInfo
 	}
 
+	// the subcommand can be explicitly enabled or disabled in the config file
+	enabled, configured := config.Subcommands[subcommandInfo.Subcommand]
+	if subcommandInfo.IsKrew && config.Plugins[subcommandInfo.Command()] == PluginPlain {
+		// a plugin configured with a printer is colorized by the registry
+		enabled, configured = false, true
+	}
+	if configured && !enabled {
+		return false, subcommandInfo
+	}
+
 	// when the command output tty is not standard output, shouldColorize depends on --force-colors flag.
 	// For example, if the command is run in a shellscript, it should not colorize. (e.g. in "kubectl completion bash")
 	// However, if user wants colored output even if the out is not tty (e.g. kubecolor get xx | grep yy)
@@ -46,34 +59,10 @@ func ResolveSubcommand(args []string, config *KubecolorConfig) (bool, *kubectl.S
 		return config.ForceColor, subcommandInfo
 	}
 
-	// else, when the given subcommand is supported, then we colorize it
-	return subcommandFound && isColoringSupported(subcommandInfo.Subcommand), subcommandInfo
-}
-
-func isColoringSupported(sc kubectl.Subcommand) bool {
-	// when you add something here, it won't be colorized
-	unsupported := []kubectl.Subcommand{
-		kubectl.Create,
-		kubectl.Debug,
-		kubectl.Delete,
-		kubectl.Edit,
-		kubectl.Attach,
-		kubectl.Replace,
-		kubectl.Completion,
-		kubectl.Exec,
-		kubectl.Proxy,
-		kubectl.Plugin,
-		kubectl.Wait,
-		kubectl.Run,
-		kubectl.Ctx,
-		kubectl.Ns,
-	}
-
-	for _, u := range unsupported {
-		if sc == u {
-			return false
-		}
+	if configured {
+		return true, subcommandInfo
 	}
 
-	return true
+	// else, when the given subcommand has a printer, then we colorize it
+	return subcommandFound && registry.Supports(subcommandInfo), subcommandInfo
 }
@@ -1,6 +1,8 @@
 package command
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hidetatz/kubecolor/kubectl"
@@ -27,7 +29,7 @@ func Test_ResolveSubcommand(t *testing.T) {
 				KubectlCmd:     "kubectl",
 			},
 			expectedShouldColorize: true,
-			expectedInfo:           &kubectl.SubcommandInfo{Subcommand: kubectl.Get},
+			expectedInfo:           &kubectl.SubcommandInfo{Subcommand: kubectl.Get, SubcommandPath: []string{"get"}, Args: []string{"pods"}, Resources: []string{"pods"}},
 		},
 		{
 			name:             "when plain, it won't colorize",
@@ -40,7 +42,7 @@ func Test_ResolveSubcommand(t *testing.T) {
 				KubectlCmd:     "kubectl",
 			},
 			expectedShouldColorize: false,
-			expectedInfo:           &kubectl.SubcommandInfo{Subcommand: kubectl.Get},
+			expectedInfo:           &kubectl.SubcommandInfo{Subcommand: kubectl.Get, SubcommandPath: []string{"get"}, Args: []string{"pods"}, Resources: []string{"pods"}},
 		},
 		{
 			name:             "when help, it will colorize",
@@ -53,7 +55,7 @@ func Test_ResolveSubcommand(t *testing.T) {
 				KubectlCmd:     "kubectl",
 			},
 			expectedShouldColorize: true,
-			expectedInfo:           &kubectl.SubcommandInfo{Subcommand: kubectl.Get, Help: true},
+			expectedInfo:           &kubectl.SubcommandInfo{Subcommand: kubectl.Get, SubcommandPath: []string{"get"}, Help: true, Args: []string{"pods"}, Resources: []string{"pods"}},
 		},
 		{
 			name:             "when both plain and force, plain is chosen",
@@ -66,7 +68,7 @@ func Test_ResolveSubcommand(t *testing.T) {
 				KubectlCmd:     "kubectl",
 			},
 			expectedShouldColorize: false,
-			expectedInfo:           &kubectl.SubcommandInfo{Subcommand: kubectl.Get},
+			expectedInfo:           &kubectl.SubcommandInfo{Subcommand: kubectl.Get, SubcommandPath: []string{"get"}, Args: []string{"pods"}, Resources: []string{"pods"}},
 		},
 		{
 			name:             "when no subcommand is found, it becomes help",
@@ -92,7 +94,7 @@ func Test_ResolveSubcommand(t *testing.T) {
 				KubectlCmd:     "kubectl",
 			},
 			expectedShouldColorize: false,
-			expectedInfo:           &kubectl.SubcommandInfo{Subcommand: kubectl.Get},
+			expectedInfo:           &kubectl.SubcommandInfo{},
 		},
 		{
 			name:             "when not tty, it won't colorize",
@@ -105,7 +107,7 @@ func Test_ResolveSubcommand(t *testing.T) {
 				KubectlCmd:     "kubectl",
 			},
 			expectedShouldColorize: false,
-			expectedInfo:           &kubectl.SubcommandInfo{Subcommand: kubectl.Get},
+			expectedInfo:           &kubectl.SubcommandInfo{Subcommand: kubectl.Get, SubcommandPath: []string{"get"}, Args: []string{"pods"}, Resources: []string{"pods"}},
 		},
 		{
 			name:             "even if not tty, if force, it colorizes",
@@ -118,7 +120,7 @@ func Test_ResolveSubcommand(t *testing.T) {
 				KubectlCmd:     "kubectl",
 			},
 			expectedShouldColorize: true,
-			expectedInfo:           &kubectl.SubcommandInfo{Subcommand: kubectl.Get},
+			expectedInfo:           &kubectl.SubcommandInfo{Subcommand: kubectl.Get, SubcommandPath: []string{"get"}, Args: []string{"pods"}, Resources: []string{"pods"}},
 		},
 		{
 			name:             "when the subcommand is unsupported, it won't colorize",
@@ -133,12 +135,134 @@ func Test_ResolveSubcommand(t *testing.T) {
 			expectedShouldColorize: true,
 			expectedInfo:           &kubectl.SubcommandInfo{Help: true},
 		},
+		{
+			name:             "when the subcommand is disabled in config, it won't colorize",
+			args:             []string{"get", "pods"},
+			isOutputTerminal: func() bool { return true },
+			conf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: true,
+				ForceColor:     true,
+				KubectlCmd:     "kubectl",
+				Subcommands:    map[kubectl.Subcommand]bool{kubectl.Get: false},
+			},
+			expectedShouldColorize: false,
+			expectedInfo:           &kubectl.SubcommandInfo{Subcommand: kubectl.Get, SubcommandPath: []string{"get"}, Args: []string{"pods"}, Resources: []string{"pods"}},
+		},
+		{
+			name:             "when the unsupported subcommand is enabled in config, it colorizes",
+			args:             []string{"exec", "pod", "--", "ls"},
+			isOutputTerminal: func() bool { return true },
+			conf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: true,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Subcommands:    map[kubectl.Subcommand]bool{kubectl.Exec: true},
+			},
+			expectedShouldColorize: true,
+			expectedInfo:           &kubectl.SubcommandInfo{Subcommand: kubectl.Exec, SubcommandPath: []string{"exec"}, Args: []string{"pod"}},
+		},
+		{
+			name:             "even if the subcommand is enabled in config, it won't colorize when not tty",
+			args:             []string{"exec", "pod", "--", "ls"},
+			isOutputTerminal: func() bool { return false },
+			conf: &KubecolorConfig{
+				Plain:          false,
+				DarkBackground: true,
+				ForceColor:     false,
+				KubectlCmd:     "kubectl",
+				Subcommands:    map[kubectl.Subcommand]bool{kubectl.Exec: true},
+			},
+			expectedShouldColorize: false,
+			expectedInfo:           &kubectl.SubcommandInfo{Subcommand: kubectl.Exec, SubcommandPath: []string{"exec"}, Args: []string{"pod"}},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			isOutputTerminal = tt.isOutputTerminal
-			shouldColorize, info := ResolveSubcommand(tt.args, tt.conf)
+			shouldColorize, info := ResolveSubcommand(tt.args, tt.conf, newRegistry(tt.conf))
+			testutil.MustEqual(t, tt.expectedShouldColorize, shouldColorize)
+			testutil.MustEqual(t, tt.expectedInfo, info)
+		})
+	}
+}
+
+func Test_ResolveSubcommand_Plugin(t *testing.T) {
+	// plugins are looked up in PATH
+	dir := t.TempDir()
+	for _, name := range []string{"kubectl-neat", "kubectl-ctx", "kubectl-view_secret"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", dir)
+
+	tests := []struct {
+		name                   string
+		args                   []string
+		plugins                map[string]string
+		expectedShouldColorize bool
+		expectedInfo           *kubectl.SubcommandInfo
+	}{
+		{
+			name:                   "a plugin is not colorized by default because it can be interactive",
+			args:                   []string{"neat", "get", "pod", "nginx"},
+			expectedShouldColorize: false,
+			expectedInfo:           &kubectl.SubcommandInfo{SubcommandPath: []string{"neat"}, Args: []string{"get", "pod", "nginx"}, IsKrew: true},
+		},
+		{
+			name:                   "a plugin configured with a printer is colorized",
+			args:                   []string{"neat", "get", "pod", "nginx"},
+			plugins:                map[string]string{"neat": "auto"},
+			expectedShouldColorize: true,
+			expectedInfo:           &kubectl.SubcommandInfo{SubcommandPath: []string{"neat"}, Args: []string{"get", "pod", "nginx"}, IsKrew: true},
+		},
+		{
+			name:                   "a plugin with dashes",
+			args:                   []string{"view-secret", "my-secret"},
+			plugins:                map[string]string{"view-secret": "yaml"},
+			expectedShouldColorize: true,
+			expectedInfo:           &kubectl.SubcommandInfo{SubcommandPath: []string{"view-secret"}, Args: []string{"my-secret"}, IsKrew: true},
+		},
+		{
+			name:                   "a plugin configured as plain is not colorized",
+			args:                   []string{"neat", "get", "pod", "nginx"},
+			plugins:                map[string]string{"neat": PluginPlain},
+			expectedShouldColorize: false,
+			expectedInfo:           &kubectl.SubcommandInfo{SubcommandPath: []string{"neat"}, Args: []string{"get", "pod", "nginx"}, IsKrew: true},
+		},
+		{
+			name:                   "kubectl ctx is not colorized by default",
+			args:                   []string{"ctx"},
+			expectedShouldColorize: false,
+			expectedInfo:           &kubectl.SubcommandInfo{Subcommand: kubectl.Ctx, SubcommandPath: []string{"ctx"}, IsKrew: true},
+		},
+		{
+			name:                   "kubectl ctx configured with a printer is colorized",
+			args:                   []string{"ctx"},
+			plugins:                map[string]string{"ctx": "text"},
+			expectedShouldColorize: true,
+			expectedInfo:           &kubectl.SubcommandInfo{Subcommand: kubectl.Ctx, SubcommandPath: []string{"ctx"}, IsKrew: true},
+		},
+		{
+			name:                   "when the plugin is not found, it becomes help",
+			args:                   []string{"tree", "deploy", "nginx"},
+			expectedShouldColorize: true,
+			expectedInfo:           &kubectl.SubcommandInfo{Help: true},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			isOutputTerminal = func() bool { return true }
+			conf := &KubecolorConfig{
+				DarkBackground: true,
+				KubectlCmd:     "kubectl",
+				Plugins:        tt.plugins,
+			}
+			shouldColorize, info := ResolveSubcommand(tt.args, conf, newRegistry(conf))
 			testutil.MustEqual(t, tt.expectedShouldColorize, shouldColorize)
 			testutil.MustEqual(t, tt.expectedInfo, info)
 		})
@@ -0,0 +1,228 @@
+package kubectl
+
+import (
+	"strings"
+)
+
+// Flag is a flag given in kubectl command line arguments.
+type Flag struct {
+	// Name is the name with dashes, e.g. "-n", "--namespace"
+	Name string
+	// Value is the value of the flag. It is empty for a boolean flag without value (e.g. "--watch").
+	Value string
+}
+
+// Args is kubectl command line arguments parsed into flags and positional arguments.
+type Args struct {
+	Flags []Flag
+	// Positional is arguments which are not flags nor their values,
+	// e.g. ["get", "pods", "nginx"] for "kubectl -n default get pods nginx -o wide"
+	Positional []string
+}
+
+// valueFlags is flags which take a value, e.g. "-n default", "--context=kind".
+// Flags which have different meanings in subcommands (e.g. "-f", "-p") are decided in isValueFlag.
+// Flags which have a default value when no value is given (e.g. --dry-run, --cascade)
+// are not here because their value must be given as "--flag=value".
+var valueFlags = map[string]bool{
+	// global flags
+	"--as":                    true,
+	"--as-group":              true,
+	"--as-uid":                true,
+	"--cache-dir":             true,
+	"--certificate-authority": true,
+	"--client-certificate":    true,
+	"--client-key":            true,
+	"--cluster":               true,
+	"--context":               true,
+	"--kubeconfig":            true,
+	"-n":                      true,
+	"--namespace":             true,
+	"--password":              true,
+	"--profile":               true,
+	"--profile-output":        true,
+	"--request-timeout":       true,
+	"-s":                      true,
+	"--server":                true,
+	"--tls-server-name":       true,
+	"--token":                 true,
+	"--user":                  true,
+	"--username":              true,
+	"-v":                      true,
+	"--v":                     true,
+	"--vmodule":               true,
+	"--log-dir":               true,
+	"--log-file":              true,
+	"--log-file-max-size":     true,
+	"--log-flush-frequency":   true,
+	"--log-backtrace-at":      true,
+	"--stderrthreshold":       true,
+
+	// output
+	"-o":              true,
+	"--output":        true,
+	"--template":      true,
+	"-L":              true,
+	"--label-columns": true,
+	"--sort-by":       true,
+	"--chunk-size":    true,
+
+	// selecting resources
+	"-l":                 true,
+	"--selector":         true,
+	"--field-selector":   true,
+	"--filename":         true,
+	"-k":                 true,
+	"--kustomize":        true,
+	"--raw":              true,
+	"--subresource":      true,
+	"--resource-version": true,
+	"--api-group":        true,
+
+	// containers and logs
+	"-c":                    true,
+	"--container":           true,
+	"--since":               true,
+	"--since-time":          true,
+	"--tail":                true,
+	"--limit-bytes":         true,
+	"--max-log-requests":    true,
+	"--pod-running-timeout": true,
+
+	// mutating resources
+	"--field-manager":     true,
+	"--timeout":           true,
+	"--grace-period":      true,
+	"--prune-allowlist":   true,
+	"--prune-whitelist":   true,
+	"--replicas":          true,
+	"--current-replicas":  true,
+	"--type":              true,
+	"--patch":             true,
+	"--patch-file":        true,
+	"--image":             true,
+	"--image-pull-policy": true,
+	"--port":              true,
+	"--target-port":       true,
+	"--protocol":          true,
+	"--name":              true,
+	"--external-ip":       true,
+	"--load-balancer-ip":  true,
+	"--session-affinity":  true,
+	"--cluster-ip":        true,
+	"-e":                  true,
+	"--env":               true,
+	"--from":              true,
+	"--to-revision":       true,
+	"--revision":          true,
+	"--min":               true,
+	"--max":               true,
+	"--cpu-percent":       true,
+	"--requests":          true,
+	"--limits":            true,
+	"--overrides":         true,
+	"--restart":           true,
+	"--serviceaccount":    true,
+	"--for":               true,
+	"--address":           true,
+	"--copy-to":           true,
+	"--target":            true,
+
+	// create
+	"--cert":            true,
+	"--key":             true,
+	"--docker-server":   true,
+	"--docker-username": true,
+	"--docker-password": true,
+	"--docker-email":    true,
+	"--from-literal":    true,
+	"--from-file":       true,
+	"--from-env-file":   true,
+	"--schedule":        true,
+	"--clusterrole":     true,
+	"--role":            true,
+	"--group":           true,
+	"--verb":            true,
+	"--resource":        true,
+	"--rule":            true,
+	"--hard":            true,
+	"--scopes":          true,
+	"--duration":        true,
+	"--audience":        true,
+
+	// explain, auth, events
+	"--api-version": true,
+	"--verbs":       true,
+	"--types":       true,
+}
+
+// isValueFlag returns true if the flag takes a value in the subcommand.
+func isValueFlag(sc Subcommand, name string) bool {
+	switch name {
+	case "-f":
+		return sc != Logs // "-f" is --follow in logs, otherwise --filename
+	case "-p":
+		return sc == Patch // "-p" is --previous in logs
+	}
+
+	return valueFlags[name]
+}
+
+// ParseArgs parses kubectl command line arguments.
+// A flag value can be given in both forms of "--flag=value" and "--flag value",
+// and also "-nvalue" for a shorthand. Shorthands of boolean flags can be combined, e.g. "-it".
+// Arguments after "--" are not parsed because they are for another command, e.g. "kubectl exec pod -- ls".
+func ParseArgs(args []string) *Args {
+	ret := &Args{}
+	sc := Subcommand(0)
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			return ret
+
+		case strings.HasPrefix(arg, "--"):
+			name, value, hasValue := strings.Cut(arg, "=")
+			if !hasValue && isValueFlag(sc, name) && i+1 < len(args) {
+				i++
+				value = args[i]
+			}
+			ret.Flags = append(ret.Flags, Flag{Name: name, Value: value})
+
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			// shorthands, e.g. "-n default", "-ndefault", "-n=default", "-it"
+			shorthands := arg[1:]
+			for j := 0; j < len(shorthands); j++ {
+				name := "-" + shorthands[j:j+1]
+				rest := shorthands[j+1:]
+
+				if strings.HasPrefix(rest, "=") {
+					ret.Flags = append(ret.Flags, Flag{Name: name, Value: rest[1:]})
+					break
+				}
+
+				if !isValueFlag(sc, name) {
+					ret.Flags = append(ret.Flags, Flag{Name: name})
+					continue
+				}
+
+				value := rest
+				if value == "" && i+1 < len(args) {
+					i++
+					value = args[i]
+				}
+				ret.Flags = append(ret.Flags, Flag{Name: name, Value: value})
+				break
+			}
+
+		default:
+			if len(ret.Positional) == 0 {
+				sc, _ = InspectSubcommand(arg)
+			}
+			ret.Positional = append(ret.Positional, arg)
+		}
+	}
+
+	return ret
+}
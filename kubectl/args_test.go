@@ -0,0 +1,99 @@
+package kubectl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		args     string
+		expected *Args
+	}{
+		{
+			"get pods",
+			&Args{Positional: []string{"get", "pods"}},
+		},
+		{
+			"-n kube-system get pods nginx",
+			&Args{
+				Flags:      []Flag{{Name: "-n", Value: "kube-system"}},
+				Positional: []string{"get", "pods", "nginx"},
+			},
+		},
+		{
+			"--namespace=kube-system --context kind get pods",
+			&Args{
+				Flags:      []Flag{{Name: "--namespace", Value: "kube-system"}, {Name: "--context", Value: "kind"}},
+				Positional: []string{"get", "pods"},
+			},
+		},
+		{
+			"get pods -nkube-system -o=json -owide",
+			&Args{
+				Flags:      []Flag{{Name: "-n", Value: "kube-system"}, {Name: "-o", Value: "json"}, {Name: "-o", Value: "wide"}},
+				Positional: []string{"get", "pods"},
+			},
+		},
+		{
+			"get pods -w --no-headers --show-labels=false",
+			&Args{
+				Flags:      []Flag{{Name: "-w"}, {Name: "--no-headers"}, {Name: "--show-labels", Value: "false"}},
+				Positional: []string{"get", "pods"},
+			},
+		},
+		{
+			"exec -it nginx -c app -- ls -l",
+			&Args{
+				Flags:      []Flag{{Name: "-i"}, {Name: "-t"}, {Name: "-c", Value: "app"}},
+				Positional: []string{"exec", "nginx"},
+			},
+		},
+		{
+			"exec -itc app nginx",
+			&Args{
+				Flags:      []Flag{{Name: "-i"}, {Name: "-t"}, {Name: "-c", Value: "app"}},
+				Positional: []string{"exec", "nginx"},
+			},
+		},
+		{
+			"logs -f -p nginx",
+			&Args{
+				Flags:      []Flag{{Name: "-f"}, {Name: "-p"}},
+				Positional: []string{"logs", "nginx"},
+			},
+		},
+		{
+			"apply -f deploy.yaml",
+			&Args{
+				Flags:      []Flag{{Name: "-f", Value: "deploy.yaml"}},
+				Positional: []string{"apply"},
+			},
+		},
+		{
+			`patch deploy nginx -p {"spec":{}}`,
+			&Args{
+				Flags:      []Flag{{Name: "-p", Value: `{"spec":{}}`}},
+				Positional: []string{"patch", "deploy", "nginx"},
+			},
+		},
+		{
+			"get pods -o",
+			&Args{
+				Flags:      []Flag{{Name: "-o"}},
+				Positional: []string{"get", "pods"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.args, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tt.expected, ParseArgs(strings.Split(tt.args, " "))); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
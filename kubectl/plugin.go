@@ -0,0 +1,45 @@
+package kubectl
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// mocked in unit tests
+var lookPath = exec.LookPath
+
+// pluginSubcommands is subcommands which are not kubectl subcommands but popular plugins,
+// e.g. "kubectl ctx" and "kubectl ns" by kubectx. They are defined as subcommands to be configured in the config file.
+var pluginSubcommands = map[Subcommand]bool{
+	Ctx: true,
+	Ns:  true,
+}
+
+// FindPlugin finds a kubectl plugin executed by args in the same way as kubectl.
+// kubectl looks up an executable named "kubectl-" and positional arguments before the first flag
+// joined with dashes, where dashes in an argument are replaced with underscores.
+// The longest name is prior, e.g. for "kubectl foo bar-baz", kubectl-foo-bar_baz then kubectl-foo are looked up.
+// It returns the arguments which make the plugin name, e.g. ["foo", "bar-baz"] for kubectl-foo-bar_baz.
+// Internal commands starting with "__" (e.g. __complete for shell completion) are not looked up,
+// because they are run frequently and PATH lookups would slow them down.
+func FindPlugin(args []string) ([]string, bool) {
+	if len(args) > 0 && strings.HasPrefix(args[0], "__") {
+		return nil, false
+	}
+
+	names := []string{}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		names = append(names, strings.ReplaceAll(arg, "-", "_"))
+	}
+
+	for n := len(names); n > 0; n-- {
+		if _, err := lookPath("kubectl-" + strings.Join(names[:n], "-")); err == nil {
+			return args[:n], true
+		}
+	}
+
+	return nil, false
+}
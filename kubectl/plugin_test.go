@@ -0,0 +1,91 @@
+package kubectl
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// mockLookPath makes only the given plugins found in PATH.
+func mockLookPath(t *testing.T, plugins ...string) {
+	t.Helper()
+	original := lookPath
+	t.Cleanup(func() { lookPath = original })
+
+	lookPath = func(file string) (string, error) {
+		for _, p := range plugins {
+			if file == p {
+				return "/usr/local/bin/" + file, nil
+			}
+		}
+		return "", errors.New("not found")
+	}
+}
+
+func TestFindPlugin(t *testing.T) {
+	mockLookPath(t, "kubectl-neat", "kubectl-view_secret", "kubectl-cnpg", "kubectl-cnpg-status", "kubectl-__complete")
+
+	tests := []struct {
+		args       string
+		expected   []string
+		expectedOK bool
+	}{
+		{"neat", []string{"neat"}, true},
+		{"neat get pod nginx", []string{"neat"}, true},
+		{"view-secret my-secret", []string{"view-secret"}, true},
+		{"view_secret my-secret", []string{"view_secret"}, true},
+		{"cnpg status cluster", []string{"cnpg", "status"}, true},
+		{"cnpg promote cluster", []string{"cnpg"}, true},
+		{"cnpg --help status", []string{"cnpg"}, true},
+		{"-n default neat", nil, false},
+		{"unknown", nil, false},
+		{"__complete neat", nil, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.args, func(t *testing.T) {
+			got, ok := FindPlugin(strings.Split(tt.args, " "))
+			if tt.expectedOK != ok {
+				t.Errorf("expected ok to be %v but got %v", tt.expectedOK, ok)
+			}
+
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestInspectSubcommandInfo_Plugin(t *testing.T) {
+	mockLookPath(t, "kubectl-neat", "kubectl-ctx", "kubectl-get")
+
+	tests := []struct {
+		args       string
+		expected   *SubcommandInfo
+		expectedOK bool
+	}{
+		{"neat get pod nginx -o yaml", &SubcommandInfo{SubcommandPath: []string{"neat"}, FormatOption: Yaml, Args: []string{"get", "pod", "nginx"}, IsKrew: true}, true},
+		{"neat -h", &SubcommandInfo{SubcommandPath: []string{"neat"}, Help: true, IsKrew: true}, true},
+		{"ctx kind", &SubcommandInfo{Subcommand: Ctx, SubcommandPath: []string{"ctx"}, Args: []string{"kind"}, IsKrew: true}, true},
+		{"ns default", &SubcommandInfo{Subcommand: Ns, SubcommandPath: []string{"ns"}, Args: []string{"default"}}, true},
+
+		// a plugin can't override kubectl subcommands
+		{"get pods", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, Args: []string{"pods"}, Resources: []string{"pods"}}, true},
+		{"tree deploy nginx", &SubcommandInfo{}, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.args, func(t *testing.T) {
+			s, ok := InspectSubcommandInfo(strings.Split(tt.args, " "))
+			if tt.expectedOK != ok {
+				t.Errorf("expected ok to be %v but got %v", tt.expectedOK, ok)
+			}
+
+			if diff := cmp.Diff(tt.expected, s); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
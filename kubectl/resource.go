@@ -0,0 +1,159 @@
+package kubectl
+
+import (
+	"strings"
+)
+
+// resourceAliases is short names and singular names of built-in resources to their plural names,
+// which are the canonical names used in SubcommandInfo.Resources.
+var resourceAliases = map[string]string{
+	"all":                       "all",
+	"cs":                        "componentstatuses",
+	"componentstatus":           "componentstatuses",
+	"cm":                        "configmaps",
+	"configmap":                 "configmaps",
+	"ep":                        "endpoints",
+	"ev":                        "events",
+	"event":                     "events",
+	"limits":                    "limitranges",
+	"limitrange":                "limitranges",
+	"ns":                        "namespaces",
+	"namespace":                 "namespaces",
+	"no":                        "nodes",
+	"node":                      "nodes",
+	"pvc":                       "persistentvolumeclaims",
+	"persistentvolumeclaim":     "persistentvolumeclaims",
+	"pv":                        "persistentvolumes",
+	"persistentvolume":          "persistentvolumes",
+	"po":                        "pods",
+	"pod":                       "pods",
+	"podtemplate":               "podtemplates",
+	"rc":                        "replicationcontrollers",
+	"replicationcontroller":     "replicationcontrollers",
+	"quota":                     "resourcequotas",
+	"resourcequota":             "resourcequotas",
+	"secret":                    "secrets",
+	"sa":                        "serviceaccounts",
+	"serviceaccount":            "serviceaccounts",
+	"svc":                       "services",
+	"service":                   "services",
+	"crd":                       "customresourcedefinitions",
+	"crds":                      "customresourcedefinitions",
+	"customresourcedefinition":  "customresourcedefinitions",
+	"ds":                        "daemonsets",
+	"daemonset":                 "daemonsets",
+	"deploy":                    "deployments",
+	"deployment":                "deployments",
+	"rs":                        "replicasets",
+	"replicaset":                "replicasets",
+	"sts":                       "statefulsets",
+	"statefulset":               "statefulsets",
+	"hpa":                       "horizontalpodautoscalers",
+	"horizontalpodautoscaler":   "horizontalpodautoscalers",
+	"cj":                        "cronjobs",
+	"cronjob":                   "cronjobs",
+	"job":                       "jobs",
+	"csr":                       "certificatesigningrequests",
+	"certificatesigningrequest": "certificatesigningrequests",
+	"lease":                     "leases",
+	"ing":                       "ingresses",
+	"ingress":                   "ingresses",
+	"ingressclass":              "ingressclasses",
+	"netpol":                    "networkpolicies",
+	"networkpolicy":             "networkpolicies",
+	"pdb":                       "poddisruptionbudgets",
+	"poddisruptionbudget":       "poddisruptionbudgets",
+	"pc":                        "priorityclasses",
+	"priorityclass":             "priorityclasses",
+	"clusterrole":               "clusterroles",
+	"clusterrolebinding":        "clusterrolebindings",
+	"role":                      "roles",
+	"rolebinding":               "rolebindings",
+	"sc":                        "storageclasses",
+	"storageclass":              "storageclasses",
+	"csidriver":                 "csidrivers",
+	"csinode":                   "csinodes",
+	"volumeattachment":          "volumeattachments",
+}
+
+// subcommandsWithResources is subcommands which take resource types as the first argument,
+// e.g. "kubectl get pods nginx", "kubectl get pods,services", "kubectl describe pod/nginx".
+var subcommandsWithResources = map[Subcommand]bool{
+	Get:       true,
+	Describe:  true,
+	Delete:    true,
+	Edit:      true,
+	Label:     true,
+	Annotate:  true,
+	Scale:     true,
+	Rollout:   true,
+	Patch:     true,
+	Expose:    true,
+	Wait:      true,
+	Autoscale: true,
+	Taint:     true,
+	Set:       true,
+	Explain:   true,
+}
+
+// NormalizeResource returns the canonical name of a resource type,
+// e.g. "po", "pod", "Pod" and "pods" are "pods". An API group is removed, e.g. "deployments.apps" is "deployments".
+// Resources which are not built-in (e.g. custom resources) are just lower-cased.
+func NormalizeResource(resource string) string {
+	resource = strings.ToLower(resource)
+	resource, _, _ = strings.Cut(resource, ".")
+
+	if r, ok := resourceAliases[resource]; ok {
+		return r
+	}
+
+	return resource
+}
+
+// inspectResources finds resource types from positional arguments of the subcommand.
+// Resource types are given in the forms of:
+//
+//	TYPE [NAME...]:        kubectl get pods nginx
+//	TYPE1,TYPE2 [NAME...]: kubectl get pods,services
+//	TYPE/NAME...:          kubectl get pod/nginx service/nginx
+//	TYPE.FIELD:            kubectl explain pods.spec.containers
+func inspectResources(sc Subcommand, args []string) []string {
+	if !subcommandsWithResources[sc] || len(args) == 0 {
+		return nil
+	}
+
+	types := []string{}
+	if sc == Explain {
+		// in explain, a resource can have field path, e.g. pods.spec.containers, which is removed in NormalizeResource
+		types = append(types, args[0])
+	} else if strings.Contains(args[0], "/") {
+		for _, arg := range args {
+			// args other than TYPE/NAME can follow, e.g. "kubectl set image deploy/nginx nginx=example.com/nginx"
+			if t, _, ok := strings.Cut(arg, "/"); ok && !strings.Contains(t, "=") {
+				types = append(types, t)
+			}
+		}
+	} else {
+		types = strings.Split(args[0], ",")
+	}
+
+	resources := []string{}
+	seen := map[string]bool{}
+	for _, t := range types {
+		if t == "" {
+			continue
+		}
+
+		r := NormalizeResource(t)
+		if !seen[r] {
+			seen[r] = true
+			resources = append(resources, r)
+		}
+	}
+
+	if len(resources) == 0 {
+		return nil
+	}
+
+	return resources
+}
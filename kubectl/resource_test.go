@@ -0,0 +1,67 @@
+package kubectl
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNormalizeResource(t *testing.T) {
+	tests := []struct {
+		resource string
+		expected string
+	}{
+		{"pods", "pods"},
+		{"pod", "pods"},
+		{"po", "pods"},
+		{"Pod", "pods"},
+		{"svc", "services"},
+		{"deploy", "deployments"},
+		{"deployments.apps", "deployments"},
+		{"deployment.v1.apps", "deployments"},
+		{"pvc", "persistentvolumeclaims"},
+		{"ev", "events"},
+		{"certificates.cert-manager.io", "certificates"},
+		{"MyResources", "myresources"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.resource, func(t *testing.T) {
+			t.Parallel()
+			if got := NormalizeResource(tt.resource); got != tt.expected {
+				t.Errorf("expected %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestInspectResources(t *testing.T) {
+	tests := []struct {
+		name     string
+		sc       Subcommand
+		args     []string
+		expected []string
+	}{
+		{"no args", Get, nil, nil},
+		{"single type", Get, []string{"po"}, []string{"pods"}},
+		{"type and names", Get, []string{"pods", "nginx", "redis"}, []string{"pods"}},
+		{"comma list", Get, []string{"po,svc,deploy.apps"}, []string{"pods", "services", "deployments"}},
+		{"comma list with duplicates", Get, []string{"pods,po,pod"}, []string{"pods"}},
+		{"trailing comma", Get, []string{"pods,"}, []string{"pods"}},
+		{"type/name", Describe, []string{"pod/nginx", "svc/nginx", "po/redis"}, []string{"pods", "services"}},
+		{"type/name with other args", Set, []string{"deploy/nginx", "nginx=example.com/nginx:1.25"}, []string{"deployments"}},
+		{"label", Label, []string{"no", "node-1", "disktype=ssd"}, []string{"nodes"}},
+		{"explain with field", Explain, []string{"deploy.spec.template"}, []string{"deployments"}},
+		{"subcommand without resources", Logs, []string{"nginx"}, nil},
+		{"subcommand without resources with slash", Exec, []string{"deploy/nginx"}, nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(inspectResources(tt.sc, tt.args), tt.expected); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
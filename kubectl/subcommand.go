@@ -1,21 +1,42 @@
 package kubectl
 
 import (
+	"strconv"
 	"strings"
 )
 
 type SubcommandInfo struct {
-	Subcommand   Subcommand
-	FormatOption FormatOption
-	NoHeader     bool
-	Watch        bool
-	Help         bool
-	Recursive    bool
-	Short        bool
+	Subcommand Subcommand
+	// SubcommandPath is the full path of the subcommand including sub-subcommands,
+	// e.g. ["config", "get-contexts"] for "kubectl config get-contexts"
+	SubcommandPath []string
+	FormatOption   FormatOption
+	NoHeader       bool
+	Watch          bool
+	Help           bool
+	Recursive      bool
+	Short          bool
 
+	// Args is positional arguments after the subcommand path, e.g. resource types and names
+	Args []string
+	// Resources is resource types given in Args in their canonical names, e.g. ["pods", "services"]
+	// for "kubectl get po,svc". See NormalizeResource.
+	Resources []string
+
+	// IsKrew is true when the command is a kubectl plugin (e.g. installed by krew), not a kubectl subcommand
 	IsKrew bool
 }
 
+// Command returns the full path of the subcommand joined with spaces, e.g. "config get-contexts".
+// When SubcommandPath is empty, it is the name of Subcommand.
+func (si *SubcommandInfo) Command() string {
+	if len(si.SubcommandPath) == 0 {
+		return subcommandName(si.Subcommand)
+	}
+
+	return strings.Join(si.SubcommandPath, " ")
+}
+
 type FormatOption int
 
 const (
@@ -23,8 +44,30 @@ const (
 	Wide
 	Json
 	Yaml
+	Name
+	CustomColumns
+	CustomColumnsFile
+	JsonPath
+	GoTemplate
 )
 
+// strToFormatOption is output formats given by "-o FORMAT" or "-o FORMAT=ARG".
+var strToFormatOption = map[string]FormatOption{
+	"wide":                Wide,
+	"json":                Json,
+	"yaml":                Yaml,
+	"name":                Name,
+	"custom-columns":      CustomColumns,
+	"custom-columns-file": CustomColumnsFile,
+	"jsonpath":            JsonPath,
+	"jsonpath-file":       JsonPath,
+	"jsonpath-as-json":    JsonPath,
+	"go-template":         GoTemplate,
+	"go-template-file":    GoTemplate,
+	"template":            GoTemplate,
+	"templatefile":        GoTemplate,
+}
+
 type Subcommand int
 
 const (
@@ -73,6 +116,7 @@ const (
 	Ctx
 	Ns
 	Debug
+	Events
 )
 
 var strToSubcommand = map[string]Subcommand{
@@ -121,6 +165,18 @@ var strToSubcommand = map[string]Subcommand{
 	"ctx":           Ctx,
 	"ns":            Ns,
 	"debug":         Debug,
+	"events":        Events,
+}
+
+// subcommandName returns the name of the subcommand, e.g. "get". It is empty if sc is not a subcommand.
+func subcommandName(sc Subcommand) string {
+	for name, s := range strToSubcommand {
+		if s == sc {
+			return name
+		}
+	}
+
+	return ""
 }
 
 func InspectSubcommand(command string) (Subcommand, bool) {
@@ -129,93 +185,75 @@ func InspectSubcommand(command string) (Subcommand, bool) {
 	return sc, ok
 }
 
-func CollectCommandlineOptions(args []string, info *SubcommandInfo) {
-	for i := range args {
-		if strings.HasPrefix(args[i], "--output") {
-			switch args[i] {
-			case "--output=json":
-				info.FormatOption = Json
-			case "--output=yaml":
-				info.FormatOption = Yaml
-			case "--output=wide":
-				info.FormatOption = Wide
-			default:
-				if len(args)-1 > i {
-					formatOption := args[i+1]
-					switch formatOption {
-					case "json":
-						info.FormatOption = Json
-					case "yaml":
-						info.FormatOption = Yaml
-					case "wide":
-						info.FormatOption = Wide
-					default:
-						// custom-columns, go-template, etc are currently not supported
-					}
-				}
-			}
-		} else if strings.HasPrefix(args[i], "-o") {
-			switch args[i] {
-			// both '-ojson' and '-o=json' works
-			case "-ojson", "-o=json":
-				info.FormatOption = Json
-			case "-oyaml", "-o=yaml":
-				info.FormatOption = Yaml
-			case "-owide", "-o=wide":
-				info.FormatOption = Wide
-			default:
-				// otherwise, look for next arg because '-o json' also works
-				if len(args)-1 > i {
-					formatOption := args[i+1]
-					switch formatOption {
-					case "json":
-						info.FormatOption = Json
-					case "yaml":
-						info.FormatOption = Yaml
-					case "wide":
-						info.FormatOption = Wide
-					default:
-						// custom-columns, go-template, etc are currently not supported
-					}
-				}
-
+// collectCommandlineOptions sets options which affect printers from flags into info.
+func collectCommandlineOptions(flags []Flag, info *SubcommandInfo) {
+	for _, f := range flags {
+		switch f.Name {
+		case "-o", "--output":
+			// a format can have its argument, e.g. "custom-columns=NAME:.metadata.name", "jsonpath={.items}"
+			format, _, _ := strings.Cut(f.Value, "=")
+			if fo, ok := strToFormatOption[format]; ok {
+				info.FormatOption = fo
 			}
-		} else if strings.HasPrefix(args[i], "--short") {
-			switch args[i] {
-			case "--short=true":
-				info.Short = true
-			case "--short=false":
-				info.Short = false
-			default:
-				info.Short = true
-			}
-		} else if args[i] == "--no-headers" {
-			info.NoHeader = true
-		} else if args[i] == "-w" || args[i] == "--watch" {
-			info.Watch = true
-		} else if args[i] == "--recursive=true" || args[i] == "--recursive" {
-			info.Recursive = true
-		} else if args[i] == "-h" || args[i] == "--help" {
-			info.Help = true
+		case "--short":
+			info.Short = isTrue(f.Value)
+		case "--no-headers":
+			info.NoHeader = isTrue(f.Value)
+		case "-w", "--watch":
+			info.Watch = isTrue(f.Value)
+		case "--recursive":
+			info.Recursive = isTrue(f.Value)
+		case "-h", "--help":
+			info.Help = isTrue(f.Value)
 		}
 	}
 }
 
-// TODO: return shouldColorize = false when the given args is for plugin
+// isTrue returns the value of a boolean flag. A boolean flag without value means true.
+func isTrue(value string) bool {
+	b, err := strconv.ParseBool(value)
+	return value == "" || (err == nil && b)
+}
+
+// InspectSubcommandInfo parses kubectl command line arguments.
+// The subcommand is the first positional argument, followed by its sub-subcommands if exist (e.g. "config get-contexts").
+// The rest of positional arguments are set in Args.
+// When the command is a kubectl plugin, IsKrew is set and SubcommandPath is the plugin name (see FindPlugin).
+// It returns false if neither the subcommand nor a plugin is found.
 func InspectSubcommandInfo(args []string) (*SubcommandInfo, bool) {
 	ret := &SubcommandInfo{}
 
-	CollectCommandlineOptions(args, ret)
+	parsed := ParseArgs(args)
+	collectCommandlineOptions(parsed.Flags, ret)
 
-	for i := range args {
-		cmd, ok := InspectSubcommand(args[i])
-		if !ok {
-			continue
+	if len(parsed.Positional) == 0 {
+		return ret, false
+	}
+
+	cmd, ok := InspectSubcommand(parsed.Positional[0])
+	if !ok || pluginSubcommands[cmd] {
+		// kubectl runs a plugin only when the command is not a kubectl subcommand
+		if path, found := FindPlugin(args); found {
+			ret.Subcommand = cmd
+			ret.SubcommandPath = path
+			if len(parsed.Positional) > len(path) {
+				ret.Args = parsed.Positional[len(path):]
+			}
+			ret.IsKrew = true
+			return ret, true
 		}
+	}
 
-		ret.Subcommand = cmd
-		return ret, true
+	if !ok {
+		return ret, false
 	}
 
-	return ret, false
+	ret.Subcommand = cmd
+	path, args := inspectSubcommandPath(cmd, parsed.Positional[0], parsed.Positional[1:])
+	ret.SubcommandPath = path
+	if len(args) > 0 {
+		ret.Args = args
+	}
+	ret.Resources = inspectResources(cmd, args)
+	return ret, true
 }
@@ -14,52 +14,90 @@ func TestInspectSubcommandInfo(t *testing.T) {
 		expected   *SubcommandInfo
 		expectedOK bool
 	}{
-		{"get pods", &SubcommandInfo{Subcommand: Get}, true},
-		{"get pod", &SubcommandInfo{Subcommand: Get}, true},
-		{"get po", &SubcommandInfo{Subcommand: Get}, true},
-
-		{"get pod -o wide", &SubcommandInfo{Subcommand: Get, FormatOption: Wide}, true},
-		{"get pod -o=wide", &SubcommandInfo{Subcommand: Get, FormatOption: Wide}, true},
-		{"get pod -owide", &SubcommandInfo{Subcommand: Get, FormatOption: Wide}, true},
-
-		{"get pod -o json", &SubcommandInfo{Subcommand: Get, FormatOption: Json}, true},
-		{"get pod -o=json", &SubcommandInfo{Subcommand: Get, FormatOption: Json}, true},
-		{"get pod -ojson", &SubcommandInfo{Subcommand: Get, FormatOption: Json}, true},
-
-		{"get pod -o yaml", &SubcommandInfo{Subcommand: Get, FormatOption: Yaml}, true},
-		{"get pod -o=yaml", &SubcommandInfo{Subcommand: Get, FormatOption: Yaml}, true},
-		{"get pod -oyaml", &SubcommandInfo{Subcommand: Get, FormatOption: Yaml}, true},
-
-		{"get pod --output json", &SubcommandInfo{Subcommand: Get, FormatOption: Json}, true},
-		{"get pod --output=json", &SubcommandInfo{Subcommand: Get, FormatOption: Json}, true},
-		{"get pod --output yaml", &SubcommandInfo{Subcommand: Get, FormatOption: Yaml}, true},
-		{"get pod --output=yaml", &SubcommandInfo{Subcommand: Get, FormatOption: Yaml}, true},
-		{"get pod --output wide", &SubcommandInfo{Subcommand: Get, FormatOption: Wide}, true},
-		{"get pod --output=wide", &SubcommandInfo{Subcommand: Get, FormatOption: Wide}, true},
-
-		{"get pod --no-headers", &SubcommandInfo{Subcommand: Get, NoHeader: true}, true},
-		{"get pod -w", &SubcommandInfo{Subcommand: Get, Watch: true}, true},
-		{"get pod --watch", &SubcommandInfo{Subcommand: Get, Watch: true}, true},
-		{"get pod -h", &SubcommandInfo{Subcommand: Get, Help: true}, true},
-		{"get pod --help", &SubcommandInfo{Subcommand: Get, Help: true}, true},
-
-		{"describe pod pod-aaa", &SubcommandInfo{Subcommand: Describe}, true},
-		{"top pod", &SubcommandInfo{Subcommand: Top}, true},
-		{"top pods", &SubcommandInfo{Subcommand: Top}, true},
-
-		{"api-versions", &SubcommandInfo{Subcommand: APIVersions}, true},
-
-		{"explain pod", &SubcommandInfo{Subcommand: Explain}, true},
-		{"explain pod --recursive=true", &SubcommandInfo{Subcommand: Explain, Recursive: true}, true},
-		{"explain pod --recursive", &SubcommandInfo{Subcommand: Explain, Recursive: true}, true},
-
-		{"version", &SubcommandInfo{Subcommand: Version}, true},
-		{"version --client", &SubcommandInfo{Subcommand: Version}, true},
-		{"version --short", &SubcommandInfo{Subcommand: Version, Short: true}, true},
-		{"version -o json", &SubcommandInfo{Subcommand: Version, FormatOption: Json}, true},
-		{"version -o yaml", &SubcommandInfo{Subcommand: Version, FormatOption: Yaml}, true},
-
-		{"apply", &SubcommandInfo{Subcommand: Apply}, true},
+		{"get pods", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, Args: []string{"pods"}, Resources: []string{"pods"}}, true},
+		{"get pod", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+		{"get po", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, Args: []string{"po"}, Resources: []string{"pods"}}, true},
+
+		{"get pod -o wide", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: Wide, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+		{"get pod -o=wide", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: Wide, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+		{"get pod -owide", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: Wide, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+
+		{"get pod -o json", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: Json, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+		{"get pod -o=json", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: Json, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+		{"get pod -ojson", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: Json, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+
+		{"get pod -o yaml", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: Yaml, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+		{"get pod -o=yaml", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: Yaml, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+		{"get pod -oyaml", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: Yaml, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+
+		{"get pod --output json", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: Json, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+		{"get pod --output=json", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: Json, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+		{"get pod --output yaml", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: Yaml, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+		{"get pod --output=yaml", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: Yaml, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+		{"get pod --output wide", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: Wide, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+		{"get pod --output=wide", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: Wide, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+
+		{"get pod --no-headers", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, NoHeader: true, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+		{"get pod -w", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, Watch: true, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+		{"get pod --watch", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, Watch: true, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+		{"get pod -h", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, Help: true, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+		{"get pod --help", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, Help: true, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+
+		{"describe pod pod-aaa", &SubcommandInfo{Subcommand: Describe, SubcommandPath: []string{"describe"}, Args: []string{"pod", "pod-aaa"}, Resources: []string{"pods"}}, true},
+		{"top pod", &SubcommandInfo{Subcommand: Top, SubcommandPath: []string{"top", "pod"}}, true},
+		{"top pods", &SubcommandInfo{Subcommand: Top, SubcommandPath: []string{"top", "pod"}}, true},
+
+		{"api-versions", &SubcommandInfo{Subcommand: APIVersions, SubcommandPath: []string{"api-versions"}}, true},
+
+		{"explain pod", &SubcommandInfo{Subcommand: Explain, SubcommandPath: []string{"explain"}, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+		{"explain pod --recursive=true", &SubcommandInfo{Subcommand: Explain, SubcommandPath: []string{"explain"}, Recursive: true, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+		{"explain pod --recursive", &SubcommandInfo{Subcommand: Explain, SubcommandPath: []string{"explain"}, Recursive: true, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+
+		{"version", &SubcommandInfo{Subcommand: Version, SubcommandPath: []string{"version"}}, true},
+		{"version --client", &SubcommandInfo{Subcommand: Version, SubcommandPath: []string{"version"}}, true},
+		{"version --short", &SubcommandInfo{Subcommand: Version, SubcommandPath: []string{"version"}, Short: true}, true},
+		{"version -o json", &SubcommandInfo{Subcommand: Version, SubcommandPath: []string{"version"}, FormatOption: Json}, true},
+		{"version -o yaml", &SubcommandInfo{Subcommand: Version, SubcommandPath: []string{"version"}, FormatOption: Yaml}, true},
+
+		{"apply", &SubcommandInfo{Subcommand: Apply, SubcommandPath: []string{"apply"}}, true},
+
+		{"-n get get pods", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, Args: []string{"pods"}, Resources: []string{"pods"}}, true},
+		{"--context logs get po", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, Args: []string{"po"}, Resources: []string{"pods"}}, true},
+		{"--context=logs get po", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, Args: []string{"po"}, Resources: []string{"pods"}}, true},
+		{"get cm describe", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, Args: []string{"cm", "describe"}, Resources: []string{"configmaps"}}, true},
+		{"get pods -l app=nginx -o json", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: Json, Args: []string{"pods"}, Resources: []string{"pods"}}, true},
+		{"logs -f nginx", &SubcommandInfo{Subcommand: Logs, SubcommandPath: []string{"logs"}, Args: []string{"nginx"}}, true},
+		{"exec -it nginx -- kubectl get pods -o json", &SubcommandInfo{Subcommand: Exec, SubcommandPath: []string{"exec"}, Args: []string{"nginx"}}, true},
+		{"get pod --no-headers=false", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, Args: []string{"pod"}, Resources: []string{"pods"}}, true},
+		{"config get-contexts", &SubcommandInfo{Subcommand: Config, SubcommandPath: []string{"config", "get-contexts"}}, true},
+		{"config use-context kind", &SubcommandInfo{Subcommand: Config, SubcommandPath: []string{"config", "use-context"}, Args: []string{"kind"}}, true},
+		{"rollout history deploy/nginx", &SubcommandInfo{Subcommand: Rollout, SubcommandPath: []string{"rollout", "history"}, Args: []string{"deploy/nginx"}, Resources: []string{"deployments"}}, true},
+		{"auth can-i get pods", &SubcommandInfo{Subcommand: Auth, SubcommandPath: []string{"auth", "can-i"}, Args: []string{"get", "pods"}}, true},
+		{"set image deploy/nginx nginx=nginx:1.25", &SubcommandInfo{Subcommand: Set, SubcommandPath: []string{"set", "image"}, Args: []string{"deploy/nginx", "nginx=nginx:1.25"}, Resources: []string{"deployments"}}, true},
+		{"create secret tls my-tls --cert cert.pem", &SubcommandInfo{Subcommand: Create, SubcommandPath: []string{"create", "secret", "tls"}, Args: []string{"my-tls"}}, true},
+		{"create -f secret", &SubcommandInfo{Subcommand: Create, SubcommandPath: []string{"create"}}, true},
+		{"top no", &SubcommandInfo{Subcommand: Top, SubcommandPath: []string{"top", "node"}}, true},
+		{"get no", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, Args: []string{"no"}, Resources: []string{"nodes"}}, true},
+		{"get po,svc,pods", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, Args: []string{"po,svc,pods"}, Resources: []string{"pods", "services"}}, true},
+		{"describe deploy/nginx svc/nginx", &SubcommandInfo{Subcommand: Describe, SubcommandPath: []string{"describe"}, Args: []string{"deploy/nginx", "svc/nginx"}, Resources: []string{"deployments", "services"}}, true},
+		{"explain pods.spec.containers", &SubcommandInfo{Subcommand: Explain, SubcommandPath: []string{"explain"}, Args: []string{"pods.spec.containers"}, Resources: []string{"pods"}}, true},
+		{"events --types Warning -w", &SubcommandInfo{Subcommand: Events, SubcommandPath: []string{"events"}, Watch: true}, true},
+		{"get ev", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, Args: []string{"ev"}, Resources: []string{"events"}}, true},
+		{"get pods -o name", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: Name, Args: []string{"pods"}, Resources: []string{"pods"}}, true},
+		{"get pods -oname", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: Name, Args: []string{"pods"}, Resources: []string{"pods"}}, true},
+		{"get pods --output=name", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: Name, Args: []string{"pods"}, Resources: []string{"pods"}}, true},
+		{"get pods -o custom-columns=NAME:.metadata.name,STATUS:.status.phase", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: CustomColumns, Args: []string{"pods"}, Resources: []string{"pods"}}, true},
+		{"get pods -o=custom-columns=NAME:.metadata.name", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: CustomColumns, Args: []string{"pods"}, Resources: []string{"pods"}}, true},
+		{"get pods --output custom-columns-file=columns.txt", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: CustomColumnsFile, Args: []string{"pods"}, Resources: []string{"pods"}}, true},
+		{"get pods -o jsonpath={.items[*].metadata.name}", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: JsonPath, Args: []string{"pods"}, Resources: []string{"pods"}}, true},
+		{"get pods -ojsonpath-file=path.txt", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: JsonPath, Args: []string{"pods"}, Resources: []string{"pods"}}, true},
+		{"get pods -o jsonpath-as-json={.items}", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: JsonPath, Args: []string{"pods"}, Resources: []string{"pods"}}, true},
+		{"get pods -o go-template={{.kind}}", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: GoTemplate, Args: []string{"pods"}, Resources: []string{"pods"}}, true},
+		{"get pods --output=go-template-file=tpl.txt", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: GoTemplate, Args: []string{"pods"}, Resources: []string{"pods"}}, true},
+		{"get pods -o template --template={{.kind}}", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: GoTemplate, Args: []string{"pods"}, Resources: []string{"pods"}}, true},
+		{"get pods -o unknown", &SubcommandInfo{Subcommand: Get, SubcommandPath: []string{"get"}, FormatOption: None, Args: []string{"pods"}, Resources: []string{"pods"}}, true},
+		{"unknown get pods", &SubcommandInfo{}, false},
+		{"-n default", &SubcommandInfo{}, false},
 
 		{"", &SubcommandInfo{}, false},
 	}
@@ -0,0 +1,132 @@
+package kubectl
+
+// subcommandNode is sub-subcommands which a subcommand has, e.g. "get-contexts" for "config".
+// A key is the name of a sub-subcommand, and its value is its children, which is nil for a leaf.
+type subcommandNode map[string]subcommandNode
+
+// subcommandTree is sub-subcommands of each subcommand.
+// Subcommands which don't have sub-subcommands are not here.
+var subcommandTree = map[Subcommand]subcommandNode{
+	Create: {
+		"clusterrole":         nil,
+		"clusterrolebinding":  nil,
+		"configmap":           nil,
+		"cronjob":             nil,
+		"deployment":          nil,
+		"ingress":             nil,
+		"job":                 nil,
+		"namespace":           nil,
+		"poddisruptionbudget": nil,
+		"priorityclass":       nil,
+		"quota":               nil,
+		"role":                nil,
+		"rolebinding":         nil,
+		"secret": {
+			"docker-registry": nil,
+			"generic":         nil,
+			"tls":             nil,
+		},
+		"service": {
+			"clusterip":    nil,
+			"externalname": nil,
+			"loadbalancer": nil,
+			"nodeport":     nil,
+		},
+		"serviceaccount": nil,
+		"token":          nil,
+	},
+	Set: {
+		"env":            nil,
+		"image":          nil,
+		"resources":      nil,
+		"selector":       nil,
+		"serviceaccount": nil,
+		"subject":        nil,
+	},
+	Rollout: {
+		"history": nil,
+		"pause":   nil,
+		"restart": nil,
+		"resume":  nil,
+		"status":  nil,
+		"undo":    nil,
+	},
+	Certificate: {
+		"approve": nil,
+		"deny":    nil,
+	},
+	ClusterInfo: {
+		"dump": nil,
+	},
+	Top: {
+		"node": nil,
+		"pod":  nil,
+	},
+	Auth: {
+		"can-i":     nil,
+		"reconcile": nil,
+		"whoami":    nil,
+	},
+	Apply: {
+		"edit-last-applied": nil,
+		"set-last-applied":  nil,
+		"view-last-applied": nil,
+	},
+	Config: {
+		"current-context": nil,
+		"delete-cluster":  nil,
+		"delete-context":  nil,
+		"delete-user":     nil,
+		"get-clusters":    nil,
+		"get-contexts":    nil,
+		"get-users":       nil,
+		"rename-context":  nil,
+		"set":             nil,
+		"set-cluster":     nil,
+		"set-context":     nil,
+		"set-credentials": nil,
+		"unset":           nil,
+		"use-context":     nil,
+		"use":             nil,
+		"view":            nil,
+	},
+	Plugin: {
+		"list": nil,
+	},
+}
+
+// subcommandAliases is aliases of sub-subcommands, e.g. "kubectl top pods" is the same as "kubectl top pod".
+var subcommandAliases = map[string]string{
+	"nodes": "node",
+	"no":    "node",
+	"pods":  "pod",
+	"po":    "pod",
+}
+
+// inspectSubcommandPath finds sub-subcommands of the subcommand from the head of args.
+// It returns the full path of the subcommand and the rest of args.
+// e.g. "config", ["get-contexts", "kind"] => ["config", "get-contexts"], ["kind"]
+func inspectSubcommandPath(sc Subcommand, name string, args []string) ([]string, []string) {
+	path := []string{name}
+	node := subcommandTree[sc]
+
+	for len(args) > 0 && node != nil {
+		name := args[0]
+		if alias, ok := subcommandAliases[name]; ok {
+			if _, ok := node[alias]; ok {
+				name = alias
+			}
+		}
+
+		child, ok := node[name]
+		if !ok {
+			break
+		}
+
+		path = append(path, name)
+		node = child
+		args = args[1:]
+	}
+
+	return path, args
+}
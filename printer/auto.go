@@ -0,0 +1,137 @@
+package printer
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// autoSniffLines is the number of lines AutoPrinter reads to find the format of the output.
+const autoSniffLines = 10
+
+// yamlKeyLine matches a line starting with a mapping key in yaml, e.g. "apiVersion: v1", "- name: nginx", "spec:"
+var yamlKeyLine = regexp.MustCompile(`^(?:- )*(?:[^\s:#'"\-][^:#]*|"[^"]*"|'[^']*'):(?: |$)`)
+
+// describeLine matches a "Key:   value" line whose value is aligned with spaces, e.g. "Name:         nginx"
+var describeLine = regexp.MustCompile(`^\s*[^\s:][^:]*:\s{2,}\S`)
+
+// AutoPrinter is a printer to print output whose format is unknown, e.g. kubectl plugins.
+// It reads the first lines to guess the format (json, yaml, table, describe-like, diff or text),
+// then prints the output by the printer for it.
+// It doesn't wait for more lines than what arrive shortly after the first line.
+type AutoPrinter struct {
+	Theme *Theme
+}
+
+func (ap *AutoPrinter) Print(r io.Reader, w io.Writer) {
+	head, rr := sniff(r, func(head []byte) bool { return bytes.Count(head, []byte("\n")) >= autoSniffLines })
+	defer rr.Close()
+
+	lines := []string{}
+	if s := strings.TrimSuffix(string(head), "\n"); s != "" {
+		lines = strings.Split(s, "\n")
+		if len(lines) > autoSniffLines {
+			lines = lines[:autoSniffLines]
+		}
+		for i := range lines {
+			lines[i] = strings.TrimRight(lines[i], "\r")
+		}
+	}
+
+	printer := ap.detectPrinter(string(head), lines)
+	printer.Print(rr, w)
+}
+
+// detectPrinter returns a printer for the output starting with the given lines.
+func (ap *AutoPrinter) detectPrinter(head string, lines []string) Printer {
+	if looksLikeJSON(head) {
+		return &JsonPrinter{Theme: ap.Theme}
+	}
+
+	nonEmpty := []string{}
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			nonEmpty = append(nonEmpty, line)
+		}
+	}
+	if len(nonEmpty) == 0 {
+		return &SingleColoredPrinter{Color: ap.Theme.Default}
+	}
+
+	switch {
+	case looksLikeDiff(nonEmpty):
+		return &DiffPrinter{Theme: ap.Theme}
+	case looksLikeTable(nonEmpty):
+		return NewTablePrinter(true, ap.Theme, nil)
+	case looksLikeDescribe(nonEmpty):
+		return &DescribePrinter{
+			Theme:        ap.Theme,
+			TablePrinter: NewTablePrinter(false, ap.Theme, nil),
+		}
+	case looksLikeYaml(nonEmpty):
+		return &YamlPrinter{Theme: ap.Theme}
+	}
+
+	return &SingleColoredPrinter{Color: ap.Theme.Default}
+}
+
+// looksLikeDiff reports whether lines are unified diff, which starts with "diff ..." or "--- a" and "+++ b".
+func looksLikeDiff(lines []string) bool {
+	if strings.HasPrefix(lines[0], "diff ") {
+		return true
+	}
+
+	return len(lines) >= 2 && strings.HasPrefix(lines[0], "--- ") && strings.HasPrefix(lines[1], "+++ ")
+}
+
+// looksLikeTable reports whether lines start with a table header, e.g. "NAME   READY   STATUS".
+func looksLikeTable(lines []string) bool {
+	header := lines[0]
+	if strings.ToUpper(header) != header || strings.ToLower(header) == header || strings.Contains(header, ":") {
+		return false
+	}
+
+	return len(spaces.Split(strings.TrimSpace(header), -1)) >= 2
+}
+
+// looksLikeDescribe reports whether lines are in kubectl describe format, where values are aligned, e.g.
+// Name:         nginx
+// Namespace:    default
+func looksLikeDescribe(lines []string) bool {
+	if !yamlKeyLine.MatchString(lines[0]) {
+		return false
+	}
+
+	for _, line := range lines {
+		if describeLine.MatchString(line) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// looksLikeYaml reports whether lines are yaml.
+// Indented lines can be anything (e.g. block scalars), so only lines without indent are checked.
+func looksLikeYaml(lines []string) bool {
+	if isDocumentMarker(lines[0]) {
+		return true
+	}
+
+	hasKey := false
+	for _, line := range lines {
+		switch {
+		case findIndent(line) > 0, strings.HasPrefix(line, "#"), isDocumentMarker(line):
+			continue
+		case yamlKeyLine.MatchString(line):
+			hasKey = true
+		case strings.HasPrefix(line, "- "), line == "-":
+			continue
+		default:
+			return false
+		}
+	}
+
+	return hasKey
+}
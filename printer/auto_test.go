@@ -0,0 +1,224 @@
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hidetatz/kubecolor/testutil"
+)
+
+func Test_AutoPrinter_Print(t *testing.T) {
+	tests := []struct {
+		name     string
+		theme    *Theme
+		input    string
+		expected string
+	}{
+		{
+			name:  "kubectl tree-like table",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				NAMESPACE  NAME                  READY  AGE
+				default    Deployment/nginx      -      19d
+				default    └─ReplicaSet/nginx    -      19d`),
+			expected: testutil.NewHereDoc(`
+				[1;37mNAMESPACE  NAME                  READY  AGE[0m
+				[36mdefault[0m    [32mDeployment/nginx[0m      [35m-[0m      [37m19d[0m
+				[36mdefault[0m    [32m└─ReplicaSet/nginx[0m    [35m-[0m      [37m19d[0m
+			`),
+		},
+		{
+			name:  "yaml",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				apiVersion: v1
+				kind: Pod
+				metadata:
+				  name: nginx
+				spec:
+				  containers:
+				  - image: nginx`),
+			expected: testutil.NewHereDoc(`
+				[33mapiVersion[0m: [36mv1[0m
+				[33mkind[0m: [36mPod[0m
+				[33mmetadata[0m:
+				  [37mname[0m: [36mnginx[0m
+				[33mspec[0m:
+				  [37mcontainers[0m:
+				  - [33mimage[0m: [36mnginx[0m
+			`),
+		},
+		{
+			name:  "yaml document",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				---
+				name: nginx`),
+			expected: testutil.NewHereDoc(`
+				[1;37m---[0m
+				[33mname[0m: [36mnginx[0m
+			`),
+		},
+		{
+			name:  "describe",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				Name:         nginx
+				Namespace:    default
+				Labels:       app=nginx`),
+			expected: testutil.NewHereDoc(`
+				[33mName[0m:         [36mnginx[0m
+				[33mNamespace[0m:    [36mdefault[0m
+				[33mLabels[0m:       [36mapp=nginx[0m
+			`),
+		},
+		{
+			name:  "diff",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				--- a/deployment.yaml
+				+++ b/deployment.yaml
+				@@ -1 +1 @@
+				-replicas: 1
+				+replicas: 3`),
+			expected: testutil.NewHereDoc(`
+				[1;37m--- a/deployment.yaml[0m
+				[1;37m+++ b/deployment.yaml[0m
+				[36m@@ -1 +1 @@[0m
+				[31m-[0m[31mreplicas: [0m[7;31m1[0m
+				[32m+[0m[32mreplicas: [0m[7;32m3[0m
+			`),
+		},
+		{
+			name:  "json",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				{
+				  "name": "nginx",
+				  "replicas": 3
+				}`),
+			expected: testutil.NewHereDoc(`
+				{
+				  "[37mname[0m": "[36mnginx[0m",
+				  "[37mreplicas[0m": [35m3[0m
+				}
+			`),
+		},
+		{
+			name:  "json array",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				[
+				  1,
+				  2
+				]`),
+			expected: testutil.NewHereDoc(`
+				[
+				  [35m1[0m,
+				  [35m2[0m
+				]
+			`),
+		},
+		{
+			name:  "text",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				Switched to context "minikube".`),
+			expected: testutil.NewHereDoc(`
+				[32mSwitched to context "minikube".[0m
+			`),
+		},
+		{
+			name:  "text with colon",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				minikube
+				error: something happened`),
+			expected: testutil.NewHereDoc(`
+				[32mminikube[0m
+				[32merror: something happened[0m
+			`),
+		},
+		{
+			name:  "text with many lines",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				line 1
+				line 2
+				line 3
+				line 4
+				line 5
+				line 6
+				line 7
+				line 8
+				line 9
+				line 10
+				line 11
+				line 12`),
+			expected: testutil.NewHereDoc(`
+				[32mline 1[0m
+				[32mline 2[0m
+				[32mline 3[0m
+				[32mline 4[0m
+				[32mline 5[0m
+				[32mline 6[0m
+				[32mline 7[0m
+				[32mline 8[0m
+				[32mline 9[0m
+				[32mline 10[0m
+				[32mline 11[0m
+				[32mline 12[0m
+			`),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := strings.NewReader(tt.input)
+			var w bytes.Buffer
+			printer := AutoPrinter{Theme: tt.theme}
+			printer.Print(r, &w)
+			testutil.MustEqual(t, tt.expected, w.String())
+		})
+	}
+}
+
+func Test_AutoPrinter_Print_Streaming(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:  "a line of text",
+			input: "Forwarding from 127.0.0.1:8080 -> 80\n",
+			expected: testutil.NewHereDoc(`
+				[32mForwarding from 127.0.0.1:8080 -> 80[0m
+			`),
+		},
+		{
+			name:  "a table header",
+			input: "NAME    READY\n",
+			expected: testutil.NewHereDoc(`
+				[1;37mNAME    READY[0m
+			`),
+		},
+		{
+			name:  "json",
+			input: "{\"level\":\"info\"}\n",
+			expected: testutil.NewHereDoc(`
+				{"[37mlevel[0m":"[36minfo[0m"}
+			`),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := printStreaming(t, &AutoPrinter{Theme: DarkTheme()}, []string{tt.input}, tt.expected)
+			testutil.MustEqual(t, tt.expected, got)
+		})
+	}
+}
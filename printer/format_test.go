@@ -3,7 +3,6 @@ package printer
 import (
 	"testing"
 
-	"github.com/google/go-cmp/cmp"
 	"github.com/hidetatz/kubecolor/color"
 )
 
@@ -16,21 +15,21 @@ func Test_toSpaces(t *testing.T) {
 func Test_getColorByKeyIndent(t *testing.T) {
 	tests := []struct {
 		name             string
-		dark             bool
+		theme            *Theme
 		indent           int
 		basicIndentWidth int
 		expected         color.Color
 	}{
-		{"dark depth: 1", true, 2, 2, color.White},
-		{"light depth: 1", false, 2, 2, color.Black},
-		{"dark depth: 2", true, 4, 2, color.Yellow},
-		{"light depth: 2", false, 4, 2, color.Yellow},
+		{"dark depth: 1", DarkTheme(), 2, 2, color.White},
+		{"light depth: 1", LightTheme(), 2, 2, color.Black},
+		{"dark depth: 2", DarkTheme(), 4, 2, color.Yellow},
+		{"light depth: 2", LightTheme(), 4, 2, color.Yellow},
 	}
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got := getColorByKeyIndent(tt.indent, tt.basicIndentWidth, tt.dark)
+			got := getColorByKeyIndent(tt.indent, tt.basicIndentWidth, tt.theme)
 			if got != tt.expected {
 				t.Errorf("fail: got: %v, expected: %v", got, tt.expected)
 			}
@@ -39,71 +38,29 @@ func Test_getColorByKeyIndent(t *testing.T) {
 }
 
 func Test_getColorByValueType(t *testing.T) {
+	theme := DarkTheme()
 	tests := []struct {
 		name     string
-		dark     bool
 		val      string
 		expected color.Color
 	}{
-		{"dark null", true, "null", NullColorForDark},
-		{"light null", false, "<none>", NullColorForLight},
+		{"null", "null", theme.Null},
+		{"none", "<none>", theme.None},
+		{"unknown", "<unknown>", theme.None},
 
-		{"dark bool", true, "true", BoolColorForDark},
-		{"light bool", false, "false", BoolColorForLight},
+		{"true", "true", theme.Bool},
+		{"false", "false", theme.Bool},
 
-		{"dark number", true, "123", NumberColorForDark},
-		{"light number", false, "456", NumberColorForLight},
+		{"number", "123", theme.Number},
 
-		{"dark string", true, "aaa", StringColorForDark},
-		{"light string", false, "12345a", StringColorForLight},
+		{"string", "aaa", theme.String},
+		{"string starting with number", "12345a", theme.String},
 	}
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got := getColorByValueType(tt.val, tt.dark)
-			if got != tt.expected {
-				t.Errorf("fail: got: %v, expected: %v", got, tt.expected)
-			}
-		})
-	}
-}
-
-func Test_getColorsByBackground(t *testing.T) {
-	tests := []struct {
-		name     string
-		dark     bool
-		expected []color.Color
-	}{
-		{"dark", true, colorsForDarkBackground},
-		{"light", false, colorsForLightBackground},
-	}
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			got := getColorsByBackground(tt.dark)
-			if diff := cmp.Diff(got, tt.expected); diff != "" {
-				t.Errorf("fail: %v", diff)
-			}
-		})
-	}
-}
-
-func Test_getHeaderColorByBackground(t *testing.T) {
-	tests := []struct {
-		name     string
-		dark     bool
-		expected color.Color
-	}{
-		{"dark", true, HeaderColorForDark},
-		{"light", false, HeaderColorForLight},
-	}
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			got := getHeaderColorByBackground(tt.dark)
+			got := getColorByValueType(tt.val, theme)
 			if got != tt.expected {
 				t.Errorf("fail: got: %v, expected: %v", got, tt.expected)
 			}
@@ -2,122 +2,187 @@ package printer
 
 import (
 	"bufio"
-	"fmt"
 	"io"
-	"strings"
+	"regexp"
 
 	"github.com/hidetatz/kubecolor/color"
 )
 
+// jsonNumber matches a number in json, e.g. "598", "-1.5", "1e+06"
+var jsonNumber = regexp.MustCompile(`^-?(?:0|[1-9]\d*)(?:\.\d+)?(?:[eE][+-]?\d+)?$`)
+
+// JsonPrinter is a printer to print json.
+// It reads json token by token instead of line by line, so it works regardless of the layout,
+// e.g. kubectl's indented json, compact json from "kubectl get --raw", or multiple json values in a stream.
+// It only adds colors; the output is the same as the input when colors are removed,
+// except that a newline is added at the end when it's missing, as other printers do.
+// Keys are colorized by their depth, and values are colorized by their type. Punctuations are not colorized.
 type JsonPrinter struct {
-	DarkBackground bool
+	Theme *Theme
 }
 
 func (jp *JsonPrinter) Print(r io.Reader, w io.Writer) {
-	scanner := bufio.NewScanner(r)
-	for scanner.Scan() {
-		line := scanner.Text()
-		printLineAsJsonFormat(line, w, jp.DarkBackground)
-	}
-}
-
-func printLineAsJsonFormat(line string, w io.Writer, dark bool) {
-	indentCnt := findIndent(line)
-	indent := toSpaces(indentCnt)
-	trimmedLine := strings.TrimLeft(line, " ")
-
-	if strings.HasPrefix(trimmedLine, "{") ||
-		strings.HasPrefix(trimmedLine, "}") ||
-		strings.HasPrefix(trimmedLine, "]") {
-		// when coming here, it must not be starting with key.
-		// that patterns are:
-		// {
-		// }
-		// },
-		// ]
-		// ],
-		// note: it must not be "[" because it will be always after key
-		// in this case, just write it without color
-		// fmt.Fprintf(w, "%s", toSpaces(indentCnt))
-		fmt.Fprintf(w, "%s%s", indent, trimmedLine)
-		fmt.Fprintf(w, "\n")
-		return
-	}
-
-	// when coming here, the line must be one of:
-	// "key": {
-	// "key": [
-	// "key": value
-	// "key": value,
-	// value,
-	// value
-	splitted := strings.SplitN(trimmedLine, ": ", 2) // if key contains ": " this works in a wrong way but it's unlikely to happen
-
-	if len(splitted) == 1 {
-		// when coming here, it will be a value in an array
-		fmt.Fprintf(w, "%s%s\n", indent, toColorizedJsonValue(splitted[0], dark))
-		return
-	}
-
-	key := splitted[0]
-	val := splitted[1]
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
 
-	fmt.Fprintf(w, "%s%s: %s\n", indent, toColorizedJsonKey(key, indentCnt, 4, dark), toColorizedJsonValue(val, dark))
+	t := &jsonTokenizer{r: bufio.NewReader(r), w: bw, theme: jp.Theme}
+	t.run()
 }
 
-// toColorizedJsonKey returns colored json key
-func toColorizedJsonKey(key string, indentCnt, basicWidth int, dark bool) string {
-	hasColon := strings.HasSuffix(key, ":")
-	// remove colon and double quotations although they might not exist actually
-	key = strings.TrimRight(key, ":")
-	doubleQuoteTrimmed := strings.TrimRight(strings.TrimLeft(key, `"`), `"`)
+// jsonTokenizer reads json from r then writes it to w colorizing each token.
+// It doesn't validate json; what it doesn't understand is written as it is.
+type jsonTokenizer struct {
+	r     *bufio.Reader
+	w     *bufio.Writer
+	theme *Theme
+	// keyOffset is added to the depth of keys to choose their colors, for json embedded in other output.
+	keyOffset int
+	// stringColor optionally overrides the color of a string value by its key and the depth of the key,
+	// e.g. the log level in json logs. The value is given as it is in json, without quotes.
+	stringColor func(key string, depth int, value string) (color.Color, bool)
+
+	// containers is '{' and '[' which the current token is in
+	containers []byte
+	// expectKey is true when the next string is a key of an object
+	expectKey bool
+	// key is the last key read
+	key string
+	// last is the last byte read, to find if the input ends with a newline
+	last byte
+}
 
-	format := `"%s"`
-	if hasColon {
-		format += ":"
+func (t *jsonTokenizer) run() {
+	for {
+		b, err := t.r.ReadByte()
+		if err != nil {
+			if t.last != 0 && t.last != '\n' {
+				t.w.WriteByte('\n')
+			}
+			return
+		}
+		t.last = b
+
+		switch b {
+		case '"':
+			t.writeString()
+		case '{', '[':
+			t.containers = append(t.containers, b)
+			t.expectKey = b == '{'
+			t.w.WriteByte(b)
+		case '}', ']':
+			if len(t.containers) > 0 {
+				t.containers = t.containers[:len(t.containers)-1]
+			}
+			t.expectKey = false
+			t.w.WriteByte(b)
+		case ',':
+			t.expectKey = t.inObject()
+			t.w.WriteByte(b)
+		case ':':
+			t.expectKey = false
+			t.w.WriteByte(b)
+		case '\n':
+			// flush on each line so that streaming output (e.g. --watch) is shown immediately
+			t.w.WriteByte(b)
+			t.w.Flush()
+		case ' ', '\t', '\r':
+			t.w.WriteByte(b)
+		default:
+			t.r.UnreadByte()
+			t.writeLiteral()
+		}
 	}
+}
 
-	return fmt.Sprintf(format, color.Apply(doubleQuoteTrimmed, getColorByKeyIndent(indentCnt, basicWidth, dark)))
+func (t *jsonTokenizer) inObject() bool {
+	return len(t.containers) > 0 && t.containers[len(t.containers)-1] == '{'
 }
 
-// toColorizedJsonValue returns colored json value.
-// This function checks it trailing comma and double quotation exist
-// then colorize the given value considering them.
-func toColorizedJsonValue(value string, dark bool) string {
-	if value == "{" {
-		return "{"
+// writeString writes a string after its opening quote. A key is colorized by its depth.
+// A string which is not closed in the line is colorized until the end of the line.
+func (t *jsonTokenizer) writeString() {
+	isKey := t.expectKey && t.inObject()
+	t.expectKey = false
+
+	var content []byte
+	closed := false
+	escaped := false
+	for {
+		b, err := t.r.ReadByte()
+		if err != nil {
+			break
+		}
+
+		if b == '\n' {
+			t.r.UnreadByte()
+			break
+		}
+
+		if b == '"' && !escaped {
+			closed = true
+			break
+		}
+
+		escaped = b == '\\' && !escaped
+		content = append(content, b)
 	}
 
-	if value == "[" {
-		return "["
+	c := t.theme.String
+	if isKey {
+		c = getColorByKeyIndent(len(t.containers)+t.keyOffset, 1, t.theme)
+		t.key = string(content)
+	} else if t.stringColor != nil {
+		if sc, ok := t.stringColor(t.key, len(t.containers), string(content)); ok {
+			c = sc
+		}
 	}
 
-	if value == "{}," {
-		return "{},"
+	t.w.WriteByte('"')
+	if len(content) > 0 {
+		t.w.WriteString(color.Apply(string(content), c))
 	}
+	if closed {
+		t.w.WriteByte('"')
+	}
+}
 
-	if value == "{}" {
-		return "{}"
+// writeLiteral writes a token which is not a string nor a punctuation, e.g. number, true, false, null.
+func (t *jsonTokenizer) writeLiteral() {
+	var literal []byte
+	for {
+		b, err := t.r.ReadByte()
+		if err != nil {
+			break
+		}
+
+		if isJSONDelimiter(b) {
+			t.r.UnreadByte()
+			break
+		}
+
+		literal = append(literal, b)
 	}
 
-	hasComma := strings.HasSuffix(value, ",")
-	// remove comma and double quotations although they might not exist actually
-	value = strings.TrimRight(value, ",")
+	s := string(literal)
+	switch {
+	case s == "true" || s == "false":
+		s = color.Apply(s, t.theme.Bool)
+	case s == "null":
+		s = color.Apply(s, t.theme.Null)
+	case jsonNumber.MatchString(s):
+		s = color.Apply(s, t.theme.Number)
+	}
 
-	isString := strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`)
-	doubleQuoteTrimmedValue := strings.TrimRight(strings.TrimLeft(value, `"`), `"`)
+	t.expectKey = false
+	t.w.WriteString(s)
+}
 
-	var format string
-	switch {
-	case hasComma && isString:
-		format = `"%s",`
-	case hasComma:
-		format = `%s,`
-	case isString:
-		format = `"%s"`
-	default:
-		format = `%s`
+// isJSONDelimiter returns true if b ends a literal.
+func isJSONDelimiter(b byte) bool {
+	switch b {
+	case ' ', '\t', '\r', '\n', '{', '}', '[', ']', ',', ':', '"':
+		return true
 	}
 
-	return fmt.Sprintf(format, color.Apply(doubleQuoteTrimmedValue, getColorByValueType(value, dark)))
+	return false
 }
@@ -2,6 +2,7 @@ package printer
 
 import (
 	"bytes"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -10,14 +11,14 @@ import (
 
 func Test_JsonPrinter_Print(t *testing.T) {
 	tests := []struct {
-		name           string
-		darkBackground bool
-		input          string
-		expected       string
+		name     string
+		theme    *Theme
+		input    string
+		expected string
 	}{
 		{
-			name:           "values can be colored by its type",
-			darkBackground: true,
+			name:  "values can be colored by its type",
+			theme: DarkTheme(),
 			input: testutil.NewHereDoc(`
 				{
 				    "apiVersion": "v1",
@@ -37,8 +38,8 @@ func Test_JsonPrinter_Print(t *testing.T) {
 			`),
 		},
 		{
-			name:           "keys can be colored by its indentation level",
-			darkBackground: true,
+			name:  "keys can be colored by its indentation level",
+			theme: DarkTheme(),
 			input: testutil.NewHereDoc(`
 				{
 				    "k1": "v1",
@@ -64,8 +65,8 @@ func Test_JsonPrinter_Print(t *testing.T) {
 			`),
 		},
 		{
-			name:           "{} and [] are not colorized",
-			darkBackground: true,
+			name:  "{} and [] are not colorized",
+			theme: DarkTheme(),
 			input: testutil.NewHereDoc(`
 				{
 				    "apiVersion": "v1",
@@ -98,6 +99,52 @@ func Test_JsonPrinter_Print(t *testing.T) {
 				}
 			`),
 		},
+		{
+			name:  "compact json is colored regardless of the layout",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				{"kind":"List","items":[{"name":"a, b","n":-1.5e3,"ok":false,"x":null,"e":""}]}`),
+			expected: testutil.NewHereDoc(`
+				{"[37mkind[0m":"[36mList[0m","[37mitems[0m":[{"[37mname[0m":"[36ma, b[0m","[37mn[0m":[35m-1.5e3[0m,"[37mok[0m":[32mfalse[0m,"[37mx[0m":[33mnull[0m,"[37me[0m":""}]}
+			`),
+		},
+		{
+			name:  "keys and strings can have quotes and colons",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				{
+				    "key: \"x\"": "v: \"q\", \\",
+				    "k": "\\"
+				}`),
+			expected: testutil.NewHereDoc(`
+				{
+				    "[37mkey: \"x\"[0m": "[36mv: \"q\", \\[0m",
+				    "[37mk[0m": "[36m\\[0m"
+				}
+			`),
+		},
+		{
+			name:  "keys in arrays are colored by their depth",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				{"a": [1, [2, {"b": true}]]}`),
+			expected: testutil.NewHereDoc(`
+				{"[37ma[0m": [[35m1[0m, [[35m2[0m, {"[33mb[0m": [32mtrue[0m}]]}
+			`),
+		},
+		{
+			name:  "multiple json values and non-json text",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				{"a": 1}
+				{"a": "not closed
+				error: not found`),
+			expected: testutil.NewHereDoc(`
+				{"[37ma[0m": [35m1[0m}
+				{"[37ma[0m": "[36mnot closed[0m
+				error: not found
+			`),
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -105,9 +152,27 @@ func Test_JsonPrinter_Print(t *testing.T) {
 			t.Parallel()
 			r := strings.NewReader(tt.input)
 			var w bytes.Buffer
-			printer := JsonPrinter{DarkBackground: tt.darkBackground}
+			printer := JsonPrinter{Theme: tt.theme}
 			printer.Print(r, &w)
 			testutil.MustEqual(t, tt.expected, w.String())
 		})
 	}
 }
+
+func Test_JsonPrinter_Print_KeepsText(t *testing.T) {
+	input := testutil.NewHereDoc(`
+		{
+		    "apiVersion": "v1",
+		    "items": [
+		        {"name": "a, b", "key: \"x\"": "v\\", "n": [1.5, -2, 3e10], "m": {}, "l": []},
+		        null,
+		        true
+		    ],
+		    "broken": "not closed
+		}
+	`)
+	var w bytes.Buffer
+	printer := JsonPrinter{Theme: DarkTheme()}
+	printer.Print(strings.NewReader(input), &w)
+	testutil.MustEqual(t, input, regexp.MustCompile("\x1b\\[[0-9;]*m").ReplaceAllString(w.String(), ""))
+}
@@ -0,0 +1,151 @@
+package printer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/hidetatz/kubecolor/color"
+)
+
+// actionKind is what an action in mutating commands did to a resource.
+type actionKind int
+
+const (
+	actionCreated actionKind = iota + 1
+	actionChanged
+	actionDeleted
+	actionNoChange
+)
+
+// actionVerb is a verb which mutating commands print for a resource, e.g. "deployment.apps/foo scaled".
+type actionVerb struct {
+	// re matches a line which has the verb. Its first submatch must be the verb.
+	re   *regexp.Regexp
+	kind actionKind
+}
+
+// suffixVerb returns an actionVerb which is printed after a resource, e.g. "deployment.apps/foo created".
+func suffixVerb(verb string, kind actionKind) actionVerb {
+	return actionVerb{re: regexp.MustCompile(` (` + verb + `)$`), kind: kind}
+}
+
+// prefixVerb returns an actionVerb which is printed before a resource, e.g. "evicting pod default/foo".
+func prefixVerb(verb string, kind actionKind) actionVerb {
+	return actionVerb{re: regexp.MustCompile(`^(` + verb + `) `), kind: kind}
+}
+
+// actionVerbs is a list of verbs printed by mutating commands.
+// The first matched one is used, so verbs which can be a suffix of others (e.g. "labeled" of "not labeled")
+// must come after them.
+var actionVerbs = []actionVerb{
+	// nothing changed
+	suffixVerb(`unchanged`, actionNoChange),                                   // apply
+	suffixVerb(`not labeled`, actionNoChange),                                 // label
+	suffixVerb(`not annotated`, actionNoChange),                               // annotate
+	suffixVerb(`patched \(no change\)`, actionNoChange),                       // patch
+	suffixVerb(`already cordoned`, actionNoChange),                            // cordon, drain
+	suffixVerb(`already uncordoned`, actionNoChange),                          // uncordon
+	suffixVerb(`skipped rollback \(.*\)`, actionNoChange),                     // rollout undo
+	suffixVerb(`image updated \(no change\)`, actionNoChange),                 // set image
+	suffixVerb(`env updated \(no change\)`, actionNoChange),                   // set env
+	suffixVerb(`resource requirements updated \(no change\)`, actionNoChange), // set resources
+
+	// created
+	suffixVerb(`created`, actionCreated),    // apply, create
+	suffixVerb(`exposed`, actionCreated),    // expose
+	suffixVerb(`autoscaled`, actionCreated), // autoscale
+
+	// changed
+	suffixVerb(`configured`, actionChanged),                    // apply
+	suffixVerb(`serverside-applied`, actionChanged),            // apply --server-side
+	suffixVerb(`scaled`, actionChanged),                        // scale
+	suffixVerb(`labeled`, actionChanged),                       // label
+	suffixVerb(`annotated`, actionChanged),                     // annotate
+	suffixVerb(`patched`, actionChanged),                       // patch
+	suffixVerb(`cordoned`, actionChanged),                      // cordon, drain
+	suffixVerb(`uncordoned`, actionChanged),                    // uncordon
+	suffixVerb(`drained`, actionChanged),                       // drain
+	suffixVerb(`tainted`, actionChanged),                       // taint
+	suffixVerb(`untainted`, actionChanged),                     // taint
+	suffixVerb(`modified`, actionChanged),                      // taint
+	suffixVerb(`restarted`, actionChanged),                     // rollout restart
+	suffixVerb(`paused`, actionChanged),                        // rollout pause
+	suffixVerb(`resumed`, actionChanged),                       // rollout resume
+	suffixVerb(`rolled back`, actionChanged),                   // rollout undo
+	suffixVerb(`image updated`, actionChanged),                 // set image
+	suffixVerb(`env updated`, actionChanged),                   // set env
+	suffixVerb(`resource requirements updated`, actionChanged), // set resources
+	suffixVerb(`serviceaccount updated`, actionChanged),        // set serviceaccount
+	suffixVerb(`selector updated`, actionChanged),              // set selector
+	suffixVerb(`subjects updated`, actionChanged),              // set subject
+	suffixVerb(`approved`, actionChanged),                      // certificate approve
+
+	// deleted or rejected
+	suffixVerb(`pruned`, actionDeleted),   // apply --prune
+	suffixVerb(`evicted`, actionDeleted),  // drain
+	prefixVerb(`evicting`, actionDeleted), // drain
+	suffixVerb(`denied`, actionDeleted),   // certificate deny
+}
+
+// dryRunSuffix matches a suffix which is added on dry run, e.g. "(dry run)", "(server dry run)"
+var dryRunSuffix = regexp.MustCompile(` (\((?:server )?dry run\))$`)
+
+// ActionPrinter is a printer to print results of mutating commands, e.g. apply, scale, label.
+// The action to a resource is colorized by what it did.
+type ActionPrinter struct {
+	Theme *Theme
+}
+
+// kubectl apply
+// deployment.apps/foo unchanged
+// deployment.apps/bar created
+// deployment.apps/quux configured
+// kubectl drain
+// node/foo cordoned
+// evicting pod default/bar
+// pod/bar evicted
+// node/foo drained
+func (ap *ActionPrinter) Print(r io.Reader, w io.Writer) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fmt.Fprintf(w, "%s\n", ap.colorizeLine(scanner.Text()))
+	}
+}
+
+func (ap *ActionPrinter) colorizeLine(line string) string {
+	// on dry run cases, it shows "xxx created (dry run)"
+	action, dryRun := line, ""
+	if m := dryRunSuffix.FindStringSubmatchIndex(line); m != nil {
+		action = line[:m[0]]
+		dryRun = " " + color.Apply(line[m[2]:m[3]], ap.Theme.DryRun)
+	}
+
+	for _, verb := range actionVerbs {
+		m := verb.re.FindStringSubmatchIndex(action)
+		if m == nil {
+			continue
+		}
+
+		return action[:m[2]] + color.Apply(action[m[2]:m[3]], ap.colorByActionKind(verb.kind)) + action[m[3]:] + dryRun
+	}
+
+	// something else. This likely won't happen but fallbacks here just in case.
+	return color.Apply(line, ap.Theme.Default)
+}
+
+func (ap *ActionPrinter) colorByActionKind(kind actionKind) color.Color {
+	switch kind {
+	case actionCreated:
+		return ap.Theme.Created
+	case actionChanged:
+		return ap.Theme.Configured
+	case actionDeleted:
+		return ap.Theme.Deleted
+	case actionNoChange:
+		return ap.Theme.Unchanged
+	}
+
+	return ap.Theme.Default
+}
@@ -0,0 +1,168 @@
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hidetatz/kubecolor/testutil"
+)
+
+func Test_ActionPrinter_Print(t *testing.T) {
+	tests := []struct {
+		name         string
+		theme        *Theme
+		tablePrinter *TablePrinter
+		input        string
+		expected     string
+	}{
+		{
+			name:  "created",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				deployment.apps/foo created`),
+			expected: testutil.NewHereDoc(`
+				deployment.apps/foo [32mcreated[0m
+			`),
+		},
+		{
+			name:  "configured",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				deployment.apps/foo configured`),
+			expected: testutil.NewHereDoc(`
+				deployment.apps/foo [33mconfigured[0m
+			`),
+		},
+		{
+			name:  "unchanged",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				deployment.apps/foo unchanged`),
+			expected: testutil.NewHereDoc(`
+				deployment.apps/foo [35munchanged[0m
+			`),
+		},
+		{
+			name:  "dry run",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				deployment.apps/foo unchanged (dry run)`),
+			expected: testutil.NewHereDoc(`
+				deployment.apps/foo [35munchanged[0m [36m(dry run)[0m
+			`),
+		},
+		{
+			name:  "server dry run",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				deployment.apps/foo serverside-applied (server dry run)`),
+			expected: testutil.NewHereDoc(`
+				deployment.apps/foo [33mserverside-applied[0m [36m(server dry run)[0m
+			`),
+		},
+		{
+			name:  "apply --prune",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				deployment.apps/foo configured
+				deployment.apps/bar pruned`),
+			expected: testutil.NewHereDoc(`
+				deployment.apps/foo [33mconfigured[0m
+				deployment.apps/bar [31mpruned[0m
+			`),
+		},
+		{
+			name:  "label and annotate",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				pod/foo labeled
+				pod/bar not labeled
+				pod/foo annotated
+				pod/bar not annotated`),
+			expected: testutil.NewHereDoc(`
+				pod/foo [33mlabeled[0m
+				pod/bar [35mnot labeled[0m
+				pod/foo [33mannotated[0m
+				pod/bar [35mnot annotated[0m
+			`),
+		},
+		{
+			name:  "scale, patch, expose and set",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				deployment.apps/foo scaled
+				deployment.apps/foo patched
+				deployment.apps/bar patched (no change)
+				service/foo exposed
+				deployment.apps/foo image updated
+				deployment.apps/foo resource requirements updated`),
+			expected: testutil.NewHereDoc(`
+				deployment.apps/foo [33mscaled[0m
+				deployment.apps/foo [33mpatched[0m
+				deployment.apps/bar [35mpatched (no change)[0m
+				service/foo [32mexposed[0m
+				deployment.apps/foo [33mimage updated[0m
+				deployment.apps/foo [33mresource requirements updated[0m
+			`),
+		},
+		{
+			name:  "cordon, uncordon and drain",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				node/foo already cordoned
+				node/bar cordoned
+				evicting pod default/nginx-6799fc88d8-2wqxz
+				pod/nginx-6799fc88d8-2wqxz evicted
+				node/bar drained
+				node/bar uncordoned
+				node/baz already uncordoned`),
+			expected: testutil.NewHereDoc(`
+				node/foo [35malready cordoned[0m
+				node/bar [33mcordoned[0m
+				[31mevicting[0m pod default/nginx-6799fc88d8-2wqxz
+				pod/nginx-6799fc88d8-2wqxz [31mevicted[0m
+				node/bar [33mdrained[0m
+				node/bar [33muncordoned[0m
+				node/baz [35malready uncordoned[0m
+			`),
+		},
+		{
+			name:  "rollout",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				deployment.apps/foo restarted
+				deployment.apps/foo paused
+				deployment.apps/foo resumed
+				deployment.apps/foo rolled back
+				deployment.apps/bar skipped rollback (current template already matches revision 2)`),
+			expected: testutil.NewHereDoc(`
+				deployment.apps/foo [33mrestarted[0m
+				deployment.apps/foo [33mpaused[0m
+				deployment.apps/foo [33mresumed[0m
+				deployment.apps/foo [33mrolled back[0m
+				deployment.apps/bar [35mskipped rollback (current template already matches revision 2)[0m
+			`),
+		},
+		{
+			name:  "something else. This likely won't happen but fallbacks here just in case.",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				deployment.apps/foo bar`),
+			expected: testutil.NewHereDoc(`
+				[32mdeployment.apps/foo bar[0m
+			`),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := strings.NewReader(tt.input)
+			var w bytes.Buffer
+			printer := ActionPrinter{Theme: tt.theme}
+			printer.Print(r, &w)
+			testutil.MustEqual(t, tt.expected, w.String())
+		})
+	}
+}
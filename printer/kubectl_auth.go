@@ -0,0 +1,51 @@
+package printer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hidetatz/kubecolor/color"
+)
+
+// AuthCanIPrinter is a printer to print kubectl auth can-i output.
+type AuthCanIPrinter struct {
+	Theme *Theme
+}
+
+// kubectl auth can-i get pods
+// yes
+// kubectl auth can-i delete nodes
+// no - RBAC: clusterrole.rbac.authorization.k8s.io "foo" not found
+// kubectl auth can-i --list
+// Resources   Non-Resource URLs   Resource Names   Verbs
+// pods        []                  []               [get list watch]
+func (ap *AuthCanIPrinter) Print(r io.Reader, w io.Writer) {
+	reader := bufio.NewReader(r)
+	first, err := reader.ReadString('\n')
+	if first == "" && err != nil {
+		return
+	}
+
+	answer, reason, _ := strings.Cut(strings.TrimSuffix(first, "\n"), " ")
+	var c color.Color
+	switch answer {
+	case "yes":
+		c = ap.Theme.Success
+	case "no":
+		c = ap.Theme.Error
+	default:
+		// --list shows a table
+		NewTablePrinter(true, ap.Theme, nil).Print(io.MultiReader(strings.NewReader(first), reader), w)
+		return
+	}
+
+	if reason != "" {
+		reason = " " + reason
+	}
+	fmt.Fprintf(w, "%s%s\n", color.Apply(answer, c), reason)
+
+	// can-i prints only one line, but just in case
+	(&SingleColoredPrinter{Color: ap.Theme.Default}).Print(reader, w)
+}
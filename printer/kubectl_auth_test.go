@@ -0,0 +1,59 @@
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hidetatz/kubecolor/testutil"
+)
+
+func Test_AuthCanIPrinter_Print(t *testing.T) {
+	tests := []struct {
+		name     string
+		theme    *Theme
+		input    string
+		expected string
+	}{
+		{
+			name:  "yes",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				yes`),
+			expected: testutil.NewHereDoc(`
+				[32myes[0m
+			`),
+		},
+		{
+			name:  "no with reason",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				no - RBAC: clusterrole.rbac.authorization.k8s.io "foo" not found`),
+			expected: testutil.NewHereDoc(`
+				[31mno[0m - RBAC: clusterrole.rbac.authorization.k8s.io "foo" not found
+			`),
+		},
+		{
+			name:  "--list",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				Resources   Non-Resource URLs   Resource Names   Verbs
+				pods        []                  []               [get list watch]`),
+			expected: testutil.NewHereDoc(`
+				[1;37mResources   Non-Resource URLs   Resource Names   Verbs[0m
+				[36mpods[0m        [32m[][0m                  [35m[][0m               [37m[get list watch][0m
+			`),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := strings.NewReader(tt.input)
+			var w bytes.Buffer
+			printer := AuthCanIPrinter{Theme: tt.theme}
+			printer.Print(r, &w)
+			testutil.MustEqual(t, tt.expected, w.String())
+		})
+	}
+}
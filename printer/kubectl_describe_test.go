@@ -10,16 +10,16 @@ import (
 
 func Test_DescribePrinter_Print(t *testing.T) {
 	tests := []struct {
-		name           string
-		darkBackground bool
-		tablePrinter   *TablePrinter
-		input          string
-		expected       string
+		name         string
+		theme        *Theme
+		tablePrinter *TablePrinter
+		input        string
+		expected     string
 	}{
 		{
-			name:           "values can be colored by its type",
-			darkBackground: true,
-			tablePrinter:   nil,
+			name:         "values can be colored by its type",
+			theme:        DarkTheme(),
+			tablePrinter: nil,
 			input: testutil.NewHereDoc(`
 				Name:         nginx-lpv5x
 				Namespace:    default
@@ -37,13 +37,13 @@ func Test_DescribePrinter_Print(t *testing.T) {
 				[33mReady[0m:        [32mtrue[0m
 				[33mStart Time[0m:   [36mSat, 10 Oct 2020 14:07:17 +0900[0m
 				[33mLabels[0m:       [36mapp=nginx[0m
-				[33mAnnotations[0m:  [33m<none>[0m
+				[33mAnnotations[0m:  [2m<none>[0m
 			`),
 		},
 		{
-			name:           "key color changes based on its indentation",
-			darkBackground: true,
-			tablePrinter:   nil,
+			name:         "key color changes based on its indentation",
+			theme:        DarkTheme(),
+			tablePrinter: nil,
 			input: testutil.NewHereDoc(`
 				IP:           172.18.0.7
 				IPs:
@@ -65,9 +65,9 @@ func Test_DescribePrinter_Print(t *testing.T) {
 			`),
 		},
 		{
-			name:           "table format in kubectl describe can be colored by describe",
-			darkBackground: true,
-			tablePrinter:   NewTablePrinter(false, true, nil),
+			name:         "table format in kubectl describe can be colored by describe",
+			theme:        DarkTheme(),
+			tablePrinter: NewTablePrinter(false, DarkTheme(), nil),
 			input: testutil.NewHereDoc(`
 				Conditions:
 				  Type             Status  LastHeartbeatTime                 LastTransitionTime                Reason                       Message
@@ -103,10 +103,10 @@ func Test_DescribePrinter_Print(t *testing.T) {
 				Events:              <none>`),
 			expected: testutil.NewHereDoc(`
 				[33mConditions[0m:
-				[36m[0m  [32mType[0m             [35mStatus[0m  [37mLastHeartbeatTime[0m                 [33mLastTransitionTime[0m                [36mReason[0m                       [32mMessage[0m
-				[36m[0m  [32m----[0m             [35m------[0m  [37m-----------------[0m                 [33m------------------[0m                [36m------[0m                       [32m-------[0m
-				[36m[0m  [32mMemoryPressure[0m   [35mFalse[0m   [37mSun, 18 Oct 2020 12:00:54 +0900[0m   [33mWed, 14 Oct 2020 09:28:18 +0900[0m   [36mKubeletHasSufficientMemory[0m   [32mkubelet has sufficient memory available[0m
-				[36m[0m  [32mDiskPressure[0m     [35mFalse[0m   [37mSun, 18 Oct 2020 12:00:54 +0900[0m   [33mWed, 14 Oct 2020 09:28:18 +0900[0m   [36mKubeletHasNoDiskPressure[0m     [32mkubelet has no disk pressure[0m
+				  [32mType[0m             [35mStatus[0m  [37mLastHeartbeatTime[0m                 [33mLastTransitionTime[0m                [36mReason[0m                       [32mMessage[0m
+				  [32m----[0m             [35m------[0m  [37m-----------------[0m                 [33m------------------[0m                [36m------[0m                       [32m-------[0m
+				  [32mMemoryPressure[0m   [35mFalse[0m   [37mSun, 18 Oct 2020 12:00:54 +0900[0m   [33mWed, 14 Oct 2020 09:28:18 +0900[0m   [36mKubeletHasSufficientMemory[0m   [32mkubelet has sufficient memory available[0m
+				  [32mDiskPressure[0m     [35mFalse[0m   [37mSun, 18 Oct 2020 12:00:54 +0900[0m   [33mWed, 14 Oct 2020 09:28:18 +0900[0m   [36mKubeletHasNoDiskPressure[0m     [32mkubelet has no disk pressure[0m
 				[33mAddresses[0m:
 				  [37mInternalIP[0m:  [36m172.17.0.3[0m
 				  [37mHostname[0m:    [36mminikube[0m
@@ -122,18 +122,18 @@ func Test_DescribePrinter_Print(t *testing.T) {
 				  [37mMachine ID[0m:                 [36m55d2ccaefc9847c9a69356e7f3bd23f4[0m
 				  [37mSystem UUID[0m:                [36mfe312784-2364-4bba-a55e-f56051539c21[0m
 				[33mNon-terminated Pods[0m:          [36m(14 in total)[0m
-				[36m[0m  [32mNamespace[0m                   [35mName[0m                                [37mCPU Requests[0m  [33mCPU Limits[0m  [36mMemory Requests[0m  [32mMemory Limits[0m  [32mAGE[0m
-				[36m[0m  [32m---------[0m                   [35m----[0m                                [37m------------[0m  [33m----------[0m  [36m---------------[0m  [32m-------------[0m  [32m---[0m
-				[36m[0m  [32mdefault[0m                     [35mnginx-6799fc88d8-dnmv5[0m              [37m0 (0%)[0m        [33m0 (0%)[0m      [36m0 (0%)[0m           [32m0 (0%)[0m         [32m7d21h[0m
-				[36m[0m  [32mdefault[0m                     [35mnginx-6799fc88d8-m8pbc[0m              [37m0 (0%)[0m        [33m0 (0%)[0m      [36m0 (0%)[0m           [32m0 (0%)[0m         [32m7d21h[0m
-				[36m[0m  [32mdefault[0m                     [35mnginx-6799fc88d8-qdf9b[0m              [37m0 (0%)[0m        [33m0 (0%)[0m      [36m0 (0%)[0m           [32m0 (0%)[0m         [32m7d21h[0m
+				  [32mNamespace[0m                   [35mName[0m                                [37mCPU Requests[0m  [33mCPU Limits[0m  [36mMemory Requests[0m  [32mMemory Limits[0m  [32mAGE[0m
+				  [32m---------[0m                   [35m----[0m                                [37m------------[0m  [33m----------[0m  [36m---------------[0m  [32m-------------[0m  [32m---[0m
+				  [32mdefault[0m                     [35mnginx-6799fc88d8-dnmv5[0m              [37m0 (0%)[0m        [33m0 (0%)[0m      [36m0 (0%)[0m           [32m0 (0%)[0m         [32m7d21h[0m
+				  [32mdefault[0m                     [35mnginx-6799fc88d8-m8pbc[0m              [37m0 (0%)[0m        [33m0 (0%)[0m      [36m0 (0%)[0m           [32m0 (0%)[0m         [32m7d21h[0m
+				  [32mdefault[0m                     [35mnginx-6799fc88d8-qdf9b[0m              [37m0 (0%)[0m        [33m0 (0%)[0m      [36m0 (0%)[0m           [32m0 (0%)[0m         [32m7d21h[0m
 				[33mAllocated resources[0m:
 				  [36m(Total limits may be over 100 percent, i.e., overcommitted.)[0m
-				[36m[0m  [32mResource[0m           [35mRequests[0m    [37mLimits[0m
-				[36m[0m  [32m--------[0m           [35m--------[0m    [37m------[0m
-				[36m[0m  [32mcpu[0m                [35m650m (10%)[0m  [37m0 (0%)[0m
-				[36m[0m  [32mmemory[0m             [35m70Mi (3%)[0m   [37m170Mi (8%)[0m
-				[33mEvents[0m:              [33m<none>[0m
+				  [32mResource[0m           [35mRequests[0m    [37mLimits[0m
+				  [32m--------[0m           [35m--------[0m    [37m------[0m
+				  [32mcpu[0m                [35m650m (10%)[0m  [37m0 (0%)[0m
+				  [32mmemory[0m             [35m70Mi (3%)[0m   [37m170Mi (8%)[0m
+				[33mEvents[0m:              [2m<none>[0m
 			`),
 		},
 		{
@@ -144,9 +144,9 @@ func Test_DescribePrinter_Print(t *testing.T) {
 			// So, this test should be removed after the kubectl fix
 			// https://github.com/kubernetes/kubectl/issues/1005
 			// For more details, see the PR description on GitHub.
-			name:           "invalid test for the workaround",
-			darkBackground: true,
-			tablePrinter:   NewTablePrinter(false, true, nil),
+			name:         "invalid test for the workaround",
+			theme:        DarkTheme(),
+			tablePrinter: NewTablePrinter(false, DarkTheme(), nil),
 			input: testutil.NewHereDoc(`
 				Name:         default
 				Labels:       <none>
@@ -165,8 +165,8 @@ func Test_DescribePrinter_Print(t *testing.T) {
 				No LimitRange resource.`),
 			expected: testutil.NewHereDoc(`
 				[33mName[0m:         [36mdefault[0m
-				[33mLabels[0m:       [33m<none>[0m
-				[33mAnnotations[0m:  [33m<none>[0m
+				[33mLabels[0m:       [2m<none>[0m
+				[33mAnnotations[0m:  [2m<none>[0m
 				[33mStatus[0m:       [36mActive[0m
 				
 				[36mResource Quotas[0m
@@ -188,7 +188,7 @@ func Test_DescribePrinter_Print(t *testing.T) {
 			t.Parallel()
 			r := strings.NewReader(tt.input)
 			var w bytes.Buffer
-			printer := DescribePrinter{DarkBackground: tt.darkBackground, TablePrinter: tt.tablePrinter}
+			printer := DescribePrinter{Theme: tt.theme, TablePrinter: tt.tablePrinter}
 			printer.Print(r, &w)
 			testutil.MustEqual(t, tt.expected, w.String())
 		})
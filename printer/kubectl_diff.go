@@ -0,0 +1,241 @@
+package printer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hidetatz/kubecolor/color"
+)
+
+// hunkHeader matches a hunk header in unified diff, e.g. "@@ -6,7 +6,7 @@ metadata:"
+var hunkHeader = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+\d+(?:,(\d+))? @@`)
+
+// diffWord matches a word or a single character which is not a part of word.
+// A line is split by it to find changed words.
+var diffWord = regexp.MustCompile(`[\p{L}\p{N}_]+|.`)
+
+// maxDiffWords is the max number of words in a line to find changed words.
+// Finding changed words takes O(n*m), so too long lines are just colorized as a whole.
+const maxDiffWords = 500
+
+// DiffPrinter is a printer to print unified diff, e.g. kubectl diff.
+type DiffPrinter struct {
+	Theme *Theme
+
+	// the number of old and new lines left in the current hunk
+	oldLinesLeft int
+	newLinesLeft int
+
+	// removed and added lines which are not printed yet, to find changed words in them
+	removed []string
+	added   []string
+}
+
+// kubectl diff
+// diff -u -N /tmp/LIVE-123/apps.v1.Deployment.default.nginx /tmp/MERGED-456/apps.v1.Deployment.default.nginx
+// --- /tmp/LIVE-123/apps.v1.Deployment.default.nginx	2023-01-02 15:04:05.000000000 +0900
+// +++ /tmp/MERGED-456/apps.v1.Deployment.default.nginx	2023-01-02 15:04:05.000000000 +0900
+// @@ -6,7 +6,7 @@ metadata:
+//
+//	  name: nginx
+//	spec:
+//
+// -  replicas: 3
+// +  replicas: 5
+func (dp *DiffPrinter) Print(r io.Reader, w io.Writer) {
+	// bufio.Scanner is not used because it can't read a line longer than its buffer,
+	// which is common in diff, e.g. last-applied-configuration annotations.
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			dp.printLine(w, strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"))
+		}
+		if err != nil {
+			break
+		}
+	}
+	dp.flush(w)
+}
+
+func (dp *DiffPrinter) printLine(w io.Writer, line string) {
+	// "\ No newline at end of file" can be after the last line of a hunk, and it is not counted as a line
+	if strings.HasPrefix(line, `\`) {
+		dp.flush(w)
+		fmt.Fprintf(w, "%s\n", line)
+		return
+	}
+
+	// outside of hunks, lines are headers. Whether a line is in a hunk or not is decided by
+	// the number of lines in the hunk header because header lines "--- " and "+++ " can't be
+	// distinguished from removed and added lines otherwise.
+	if dp.oldLinesLeft <= 0 && dp.newLinesLeft <= 0 {
+		dp.flush(w)
+		if m := hunkHeader.FindStringSubmatch(line); m != nil {
+			dp.oldLinesLeft = hunkLineCount(m[1])
+			dp.newLinesLeft = hunkLineCount(m[2])
+			fmt.Fprintf(w, "%s%s\n", color.Apply(m[0], dp.Theme.DiffHunk), strings.TrimPrefix(line, m[0]))
+			return
+		}
+
+		fmt.Fprintf(w, "%s\n", color.Apply(line, dp.Theme.DiffHeader))
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(line, "-"):
+		// removed lines after added lines are not paired with them
+		if len(dp.added) > 0 {
+			dp.flush(w)
+		}
+		dp.oldLinesLeft--
+		dp.removed = append(dp.removed, line)
+	case strings.HasPrefix(line, "+"):
+		dp.newLinesLeft--
+		dp.added = append(dp.added, line)
+	default:
+		dp.oldLinesLeft--
+		dp.newLinesLeft--
+		dp.flush(w)
+		fmt.Fprintf(w, "%s\n", line)
+	}
+
+	if dp.oldLinesLeft <= 0 && dp.newLinesLeft <= 0 {
+		dp.flush(w)
+	}
+}
+
+// flush prints pending removed and added lines.
+// When the numbers of removed and added lines are the same, they are paired to highlight changed words.
+func (dp *DiffPrinter) flush(w io.Writer) {
+	removed := make([]string, len(dp.removed))
+	added := make([]string, len(dp.added))
+	if len(dp.removed) == len(dp.added) {
+		for i := range dp.removed {
+			removed[i], added[i] = dp.highlightChangedWords(dp.removed[i], dp.added[i])
+		}
+	} else {
+		for i, line := range dp.removed {
+			removed[i] = color.Apply(line, dp.Theme.DiffRemoved)
+		}
+		for i, line := range dp.added {
+			added[i] = color.Apply(line, dp.Theme.DiffAdded)
+		}
+	}
+
+	for _, line := range append(removed, added...) {
+		fmt.Fprintf(w, "%s\n", line)
+	}
+
+	dp.removed = nil
+	dp.added = nil
+}
+
+// highlightChangedWords colorizes a pair of removed and added lines,
+// highlighting words which exist only in either of them.
+func (dp *DiffPrinter) highlightChangedWords(removed, added string) (string, string) {
+	oldWords := diffWord.FindAllString(removed[1:], -1)
+	newWords := diffWord.FindAllString(added[1:], -1)
+	if len(oldWords) > maxDiffWords || len(newWords) > maxDiffWords {
+		return color.Apply(removed, dp.Theme.DiffRemoved), color.Apply(added, dp.Theme.DiffAdded)
+	}
+
+	oldCommon, newCommon := commonWords(oldWords, newWords)
+
+	// when the lines have nothing in common but spaces, highlighting the whole line doesn't help
+	if !hasCommonWord(oldWords, oldCommon) {
+		return color.Apply(removed, dp.Theme.DiffRemoved), color.Apply(added, dp.Theme.DiffAdded)
+	}
+
+	return color.Apply("-", dp.Theme.DiffRemoved) + dp.colorizeWords(oldWords, oldCommon, dp.Theme.DiffRemoved, dp.Theme.DiffRemovedWord),
+		color.Apply("+", dp.Theme.DiffAdded) + dp.colorizeWords(newWords, newCommon, dp.Theme.DiffAdded, dp.Theme.DiffAddedWord)
+}
+
+// colorizeWords joins words colorizing common ones in c and others in changed.
+// Consecutive words in the same color are colorized at once.
+func (dp *DiffPrinter) colorizeWords(words []string, common []bool, c, changed color.Color) string {
+	var sb strings.Builder
+	start := 0
+	for i := 1; i <= len(words); i++ {
+		if i < len(words) && common[i] == common[start] {
+			continue
+		}
+
+		cc := changed
+		if common[start] {
+			cc = c
+		}
+		sb.WriteString(color.Apply(strings.Join(words[start:i], ""), cc))
+		start = i
+	}
+
+	return sb.String()
+}
+
+// commonWords finds the longest common subsequence of a and b,
+// then returns which words in a and b are in it.
+func commonWords(a, b []string) ([]bool, []bool) {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	aCommon := make([]bool, len(a))
+	bCommon := make([]bool, len(b))
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			aCommon[i] = true
+			bCommon[j] = true
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	return aCommon, bCommon
+}
+
+// hasCommonWord returns true if any of common words is not a space.
+func hasCommonWord(words []string, common []bool) bool {
+	for i, word := range words {
+		if common[i] && strings.TrimSpace(word) != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hunkLineCount returns the number of lines in a hunk header. It is omitted when it's 1.
+func hunkLineCount(s string) int {
+	if s == "" {
+		return 1
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 1
+	}
+
+	return n
+}
@@ -0,0 +1,129 @@
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hidetatz/kubecolor/testutil"
+)
+
+func Test_DiffPrinter_Print(t *testing.T) {
+	tests := []struct {
+		name     string
+		theme    *Theme
+		input    string
+		expected string
+	}{
+		{
+			name:  "changed words are highlighted",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				diff -u -N /tmp/LIVE-123/apps.v1.Deployment.default.nginx /tmp/MERGED-456/apps.v1.Deployment.default.nginx
+				--- /tmp/LIVE-123/apps.v1.Deployment.default.nginx	2023-01-02 15:04:05.000000000 +0900
+				+++ /tmp/MERGED-456/apps.v1.Deployment.default.nginx	2023-01-02 15:04:05.000000000 +0900
+				@@ -6,5 +6,5 @@ metadata:
+				   name: nginx
+				 spec:
+				-  replicas: 3
+				+  replicas: 5
+				   selector:
+				     matchLabels:`),
+			expected: testutil.NewHereDoc(`
+				[1;37mdiff -u -N /tmp/LIVE-123/apps.v1.Deployment.default.nginx /tmp/MERGED-456/apps.v1.Deployment.default.nginx[0m
+				[1;37m--- /tmp/LIVE-123/apps.v1.Deployment.default.nginx	2023-01-02 15:04:05.000000000 +0900[0m
+				[1;37m+++ /tmp/MERGED-456/apps.v1.Deployment.default.nginx	2023-01-02 15:04:05.000000000 +0900[0m
+				[36m@@ -6,5 +6,5 @@[0m metadata:
+				   name: nginx
+				 spec:
+				[31m-[0m[31m  replicas: [0m[7;31m3[0m
+				[32m+[0m[32m  replicas: [0m[7;32m5[0m
+				   selector:
+				     matchLabels:
+			`),
+		},
+		{
+			name:  "lines starting with --- or +++ in a hunk are not headers",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				--- a
+				+++ b
+				@@ -1 +1 @@
+				--- old
+				+++ new
+				@@ -10 +10 @@
+				-a
+				+b`),
+			expected: testutil.NewHereDoc(`
+				[1;37m--- a[0m
+				[1;37m+++ b[0m
+				[36m@@ -1 +1 @@[0m
+				[31m--- old[0m
+				[32m+++ new[0m
+				[36m@@ -10 +10 @@[0m
+				[31m-a[0m
+				[32m+b[0m
+			`),
+		},
+		{
+			name:  "not paired lines are not highlighted",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				@@ -1,3 +1,4 @@
+				 metadata:
+				-  labels: {}
+				+  labels:
+				+    app: nginx
+				 spec:
+				\ No newline at end of file`),
+			expected: testutil.NewHereDoc(`
+				[36m@@ -1,3 +1,4 @@[0m
+				 metadata:
+				[31m-  labels: {}[0m
+				[32m+  labels:[0m
+				[32m+    app: nginx[0m
+				 spec:
+				\ No newline at end of file
+			`),
+		},
+		{
+			name:  "new file",
+			theme: LightTheme(),
+			input: testutil.NewHereDoc(`
+				@@ -0,0 +1,2 @@
+				+apiVersion: v1
+				+kind: ConfigMap`),
+			expected: testutil.NewHereDoc(`
+				[36m@@ -0,0 +1,2 @@[0m
+				[32m+apiVersion: v1[0m
+				[32m+kind: ConfigMap[0m
+			`),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := strings.NewReader(tt.input)
+			var w bytes.Buffer
+			printer := DiffPrinter{Theme: tt.theme}
+			printer.Print(r, &w)
+			testutil.MustEqual(t, tt.expected, w.String())
+		})
+	}
+}
+
+func Test_DiffPrinter_Print_LongLine(t *testing.T) {
+	x := strings.Repeat("x", 1024*1024)
+	y := strings.Repeat("y", 1024*1024)
+	input := "@@ -1,2 +1,2 @@\n-  a: " + x + "\n+  a: " + y + "\n   b: 1\n"
+	expected := "\x1b[36m@@ -1,2 +1,2 @@\x1b[0m\n" +
+		"\x1b[31m-\x1b[0m\x1b[31m  a: \x1b[0m\x1b[7;31m" + x + "\x1b[0m\n" +
+		"\x1b[32m+\x1b[0m\x1b[32m  a: \x1b[0m\x1b[7;32m" + y + "\x1b[0m\n" +
+		"   b: 1\n"
+
+	var w bytes.Buffer
+	printer := DiffPrinter{Theme: DarkTheme()}
+	printer.Print(strings.NewReader(input), &w)
+	testutil.MustEqual(t, expected, w.String())
+}
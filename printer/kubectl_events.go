@@ -0,0 +1,56 @@
+package printer
+
+import (
+	"github.com/hidetatz/kubecolor/color"
+)
+
+// eventFailureReasons is REASONs of events which are worth noticing during incidents.
+var eventFailureReasons = map[string]bool{
+	"BackOff":                true,
+	"Failed":                 true,
+	"FailedScheduling":       true,
+	"FailedMount":            true,
+	"FailedAttachVolume":     true,
+	"FailedCreatePodSandBox": true,
+	"FailedKillPod":          true,
+	"Unhealthy":              true,
+	"OOMKilling":             true,
+	"Evicted":                true,
+	"NodeNotReady":           true,
+}
+
+// eventColumnDeciders is deciders for kubectl get events and kubectl events.
+// kubectl get events
+// LAST SEEN   TYPE      REASON             OBJECT           MESSAGE
+// 2m          Normal    Scheduled          pod/nginx        Successfully assigned default/nginx to node-1
+// 1m          Warning   BackOff            pod/nginx        Back-off restarting failed container
+var eventColumnDeciders = []columnDecider{decideEventTypeColumn, decideEventReasonColumn}
+
+// decideEventTypeColumn colorizes TYPE of events. Warning stands out and Normal is dimmed.
+func decideEventTypeColumn(header, column string, theme *Theme) (color.Color, bool) {
+	if header != "" && header != "TYPE" {
+		return 0, false
+	}
+
+	switch column {
+	case "Warning":
+		return theme.Warning, true
+	case "Normal":
+		return theme.None, true
+	}
+
+	return 0, false
+}
+
+// decideEventReasonColumn colorizes REASON of events when it is a failure, e.g. BackOff, FailedScheduling.
+func decideEventReasonColumn(header, column string, theme *Theme) (color.Color, bool) {
+	if header != "" && header != "REASON" {
+		return 0, false
+	}
+
+	if eventFailureReasons[column] {
+		return theme.Error, true
+	}
+
+	return 0, false
+}
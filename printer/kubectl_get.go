@@ -0,0 +1,181 @@
+package printer
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hidetatz/kubecolor/color"
+)
+
+// columnDecider decides a color of a column in kubectl get.
+// It returns false when the column is not what it cares about.
+// header is empty when --no-headers is specified, so the column must be decided by its content then.
+type columnDecider func(header, column string, theme *Theme) (color.Color, bool)
+
+// defaultColumnDeciders is used when resources are unknown or multiple resources are printed,
+// e.g. "kubectl get all", "kubectl get pods,nodes", "kubectl get -f manifest.yaml", custom resources.
+var defaultColumnDeciders = []columnDecider{decideStatusColumn, decideReadyColumn}
+
+// resourceColumnDeciders is deciders for each resource kind. Keys are canonical names (see kubectl.NormalizeResource).
+// An empty list means the resource doesn't have columns to colorize,
+// which prevents names from being colorized by accident with --no-headers.
+var resourceColumnDeciders = map[string][]columnDecider{
+	"pods":                   {decideStatusColumn, decideReadyColumn},
+	"nodes":                  {decideStatusColumn},
+	"namespaces":             {decideStatusColumn},
+	"persistentvolumes":      {decideStatusColumn},
+	"persistentvolumeclaims": {decideStatusColumn},
+	"deployments":            {decideReadyColumn},
+	"statefulsets":           {decideReadyColumn},
+	"replicationcontrollers": {decideReadyColumn},
+	"jobs":                   {decideStatusColumn, decideCompletionsColumn},
+	"events":                 eventColumnDeciders,
+	"configmaps":             {},
+	"secrets":                {},
+	"serviceaccounts":        {},
+	"services":               {},
+	"endpoints":              {},
+}
+
+// kubectlDuration matches a duration which kubectl prints, e.g. "5s", "3m12s", "7d10h", "2y3d"
+var kubectlDuration = regexp.MustCompile(`^(?:(\d+)y)?(?:(\d+)d)?(?:(\d+)h)?(?:(\d+)m)?(?:(\d+)s)?$`)
+
+// durations less than them are colorized as fresh or recent ones in AGE and LAST SEEN
+const (
+	freshAge  = 10 * time.Minute
+	recentAge = 24 * time.Hour
+)
+
+// newGetColorDecider returns a ColorDeciderFn of TablePrinter for kubectl get of the resources.
+// Resource specific deciders are used only when exactly one resource kind is printed
+// because columns are different between resource kinds.
+// Columns which mean the same in any resources (e.g. RESTARTS, AGE) are decided by their header.
+func newGetColorDecider(resources []string, theme *Theme, restarts Thresholds) func(header, column string) (color.Color, bool) {
+	deciders := defaultColumnDeciders
+	if len(resources) == 1 {
+		if d, ok := resourceColumnDeciders[resources[0]]; ok {
+			deciders = d
+		}
+	}
+	deciders = append(deciders[:len(deciders):len(deciders)], decideRestartsColumn(restarts), decideAgeColumn)
+
+	return func(header, column string) (color.Color, bool) {
+		for _, decide := range deciders {
+			if c, ok := decide(header, column, theme); ok {
+				return c, true
+			}
+		}
+
+		return 0, false
+	}
+}
+
+// decideStatusColumn colorizes STATUS by its meaning, e.g. Running, Pending, CrashLoopBackOff.
+func decideStatusColumn(header, column string, theme *Theme) (color.Color, bool) {
+	if header != "" && header != "STATUS" {
+		return 0, false
+	}
+
+	return getColorByStatus(column, theme)
+}
+
+// decideReadyColumn colorizes READY when not all are ready, e.g. "1/2".
+func decideReadyColumn(header, column string, theme *Theme) (color.Color, bool) {
+	if header != "" && header != "READY" {
+		return 0, false
+	}
+
+	return decideRatio(column, theme)
+}
+
+// decideCompletionsColumn colorizes COMPLETIONS of jobs when not all are completed, e.g. "0/1".
+func decideCompletionsColumn(header, column string, theme *Theme) (color.Color, bool) {
+	if header != "" && header != "COMPLETIONS" {
+		return 0, false
+	}
+
+	return decideRatio(column, theme)
+}
+
+// decideRestartsColumn returns a decider to colorize RESTARTS by the thresholds.
+// RESTARTS can have when the last restart was, e.g. "5 (3m ago)".
+func decideRestartsColumn(thresholds Thresholds) columnDecider {
+	return func(header, column string, theme *Theme) (color.Color, bool) {
+		if header != "RESTARTS" {
+			return 0, false
+		}
+
+		count, _, _ := strings.Cut(column, " ")
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			return 0, false
+		}
+
+		return thresholds.color(n, theme)
+	}
+}
+
+// decideAgeColumn colorizes AGE and LAST SEEN by recency, so things which have just started or happened stand out.
+// LAST SEEN of kubectl events can have how many times it happened, e.g. "2m (x4 over 10m)".
+func decideAgeColumn(header, column string, theme *Theme) (color.Color, bool) {
+	if header != "AGE" && header != "LAST SEEN" {
+		return 0, false
+	}
+
+	age, _, _ := strings.Cut(column, " ")
+	d, ok := parseKubectlDuration(age)
+	if !ok {
+		return 0, false
+	}
+
+	switch {
+	case d < freshAge:
+		return theme.AgeFresh, true
+	case d < recentAge:
+		return theme.AgeRecent, true
+	}
+
+	return 0, false
+}
+
+// parseKubectlDuration parses a duration which kubectl prints. A year is treated as 365 days as kubectl does.
+func parseKubectlDuration(s string) (time.Duration, bool) {
+	m := kubectlDuration.FindStringSubmatch(s)
+	if s == "" || m == nil {
+		return 0, false
+	}
+
+	units := []time.Duration{365 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+1] == "" {
+			continue
+		}
+
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return 0, false
+		}
+		d += time.Duration(n) * unit
+	}
+
+	return d, true
+}
+
+// decideRatio returns the warning color when the column is "n/m" and n is not m.
+func decideRatio(column string, theme *Theme) (color.Color, bool) {
+	n, m, ok := strings.Cut(column, "/")
+	if !ok || n == m {
+		return 0, false
+	}
+
+	_, e1 := strconv.Atoi(n)
+	_, e2 := strconv.Atoi(m)
+	if e1 != nil || e2 != nil { // check both is number
+		return 0, false
+	}
+
+	return theme.Warning, true
+}
@@ -0,0 +1,85 @@
+package printer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hidetatz/kubecolor/color"
+	"github.com/hidetatz/kubecolor/testutil"
+)
+
+func Test_newGetColorDecider(t *testing.T) {
+	theme := DarkTheme()
+	tests := []struct {
+		name       string
+		resources  []string
+		restarts   Thresholds
+		header     string
+		column     string
+		expected   color.Color
+		expectedOK bool
+	}{
+		{"status", nil, DefaultRestartsThresholds, "STATUS", "Running", theme.Success, true},
+		{"status without header", nil, DefaultRestartsThresholds, "", "CrashLoopBackOff", theme.Error, true},
+		{"not ready", nil, DefaultRestartsThresholds, "READY", "1/2", theme.Warning, true},
+		{"ready", nil, DefaultRestartsThresholds, "READY", "2/2", 0, false},
+		{"completions of jobs", []string{"jobs"}, DefaultRestartsThresholds, "COMPLETIONS", "0/1", theme.Warning, true},
+		{"completions of others", nil, DefaultRestartsThresholds, "COMPLETIONS", "0/1", 0, false},
+		{"name of configmaps without header", []string{"configmaps"}, DefaultRestartsThresholds, "", "Pending", 0, false},
+		{"event type", []string{"events"}, DefaultRestartsThresholds, "TYPE", "Warning", theme.Warning, true},
+		{"service type", []string{"services"}, DefaultRestartsThresholds, "TYPE", "Warning", 0, false},
+
+		{"no restarts", []string{"pods"}, DefaultRestartsThresholds, "RESTARTS", "0", 0, false},
+		{"some restarts", []string{"pods"}, DefaultRestartsThresholds, "RESTARTS", "3", theme.Warning, true},
+		{"some restarts with last restart", []string{"pods"}, DefaultRestartsThresholds, "RESTARTS", "5 (3m ago)", theme.Warning, true},
+		{"many restarts", []string{"pods"}, DefaultRestartsThresholds, "RESTARTS", "412 (10s ago)", theme.Error, true},
+		{"restarts under custom thresholds", []string{"pods"}, Thresholds{Warning: 5, Error: 100}, "RESTARTS", "3", 0, false},
+		{"restarts over custom thresholds", []string{"pods"}, Thresholds{Warning: 5, Error: 100}, "RESTARTS", "100 (1d ago)", theme.Error, true},
+		{"restarts without header", []string{"pods"}, DefaultRestartsThresholds, "", "412", 0, false},
+
+		{"fresh age", nil, DefaultRestartsThresholds, "AGE", "5s", theme.AgeFresh, true},
+		{"fresh age in minutes", nil, DefaultRestartsThresholds, "AGE", "3m12s", theme.AgeFresh, true},
+		{"recent age", nil, DefaultRestartsThresholds, "AGE", "7h", theme.AgeRecent, true},
+		{"old age", nil, DefaultRestartsThresholds, "AGE", "19d", 0, false},
+		{"last seen with count", []string{"events"}, DefaultRestartsThresholds, "LAST SEEN", "2m (x4 over 10m)", theme.AgeFresh, true},
+		{"unknown age", nil, DefaultRestartsThresholds, "AGE", "<unknown>", 0, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c, ok := newGetColorDecider(tt.resources, theme, tt.restarts)(tt.header, tt.column)
+			testutil.MustEqual(t, tt.expectedOK, ok)
+			if ok {
+				testutil.MustEqual(t, tt.expected, c)
+			}
+		})
+	}
+}
+
+func Test_parseKubectlDuration(t *testing.T) {
+	tests := []struct {
+		s          string
+		expected   time.Duration
+		expectedOK bool
+	}{
+		{"5s", 5 * time.Second, true},
+		{"3m12s", 3*time.Minute + 12*time.Second, true},
+		{"10h", 10 * time.Hour, true},
+		{"7d10h", 7*24*time.Hour + 10*time.Hour, true},
+		{"2y3d", 2*365*24*time.Hour + 3*24*time.Hour, true},
+		{"", 0, false},
+		{"<invalid>", 0, false},
+		{"5", 0, false},
+		{"10s5m", 0, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.s, func(t *testing.T) {
+			t.Parallel()
+			d, ok := parseKubectlDuration(tt.s)
+			testutil.MustEqual(t, tt.expectedOK, ok)
+			testutil.MustEqual(t, tt.expected, d)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package printer
+
+import (
+	"bufio"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/hidetatz/kubecolor/color"
+)
+
+// logLevel is a severity of a log line.
+type logLevel int
+
+const (
+	logLevelUnknown logLevel = iota
+	logLevelDebug
+	logLevelInfo
+	logLevelWarn
+	logLevelError
+)
+
+// logLevels is a vocabulary of log levels. Keys are lower case.
+var logLevels = map[string]logLevel{
+	"trace":    logLevelDebug,
+	"debug":    logLevelDebug,
+	"dbg":      logLevelDebug,
+	"info":     logLevelInfo,
+	"inf":      logLevelInfo,
+	"notice":   logLevelInfo,
+	"warn":     logLevelWarn,
+	"warning":  logLevelWarn,
+	"wrn":      logLevelWarn,
+	"error":    logLevelError,
+	"err":      logLevelError,
+	"fatal":    logLevelError,
+	"panic":    logLevelError,
+	"critical": logLevelError,
+	"crit":     logLevelError,
+	"alert":    logLevelError,
+	"emerg":    logLevelError,
+}
+
+var (
+	// prefix added by kubectl logs --prefix, e.g. "[pod/nginx-6799fc88d8-2wqxz/nginx] "
+	logPrefix = regexp.MustCompile(`^\[[^\s\[\]]+/[^\s\[\]]+\] `)
+	// timestamp added by kubectl logs --timestamps, e.g. "2023-01-02T15:04:05.999999999Z "
+	logTimestamp = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2}) `)
+	// klog header, e.g. "E0102 15:04:05.999999    1234 file.go:123] message"
+	klogHeader = regexp.MustCompile(`^([IWEF])\d{4} \d{2}:\d{2}:\d{2}`)
+	// json log, e.g. {"level":"info","msg":"message"}
+	jsonLogLevel = regexp.MustCompile(`"(?:level|lvl|severity)"\s*:\s*"(\w+)"`)
+	// logfmt log, e.g. time=... level=info msg=message
+	logfmtLogLevel = regexp.MustCompile(`(?:^|\s)(?:level|lvl|severity)="?(\w+)`)
+	// plain text log, e.g. "2023/01/02 15:04:05 [ERROR] message", "ERROR: message", "[main] [WARN] message"
+	// The level must be at the start of the line (after a timestamp if any) or in brackets
+	// so that a word in the message like "no ERROR found" is not taken as the level.
+	plainLogLevel = regexp.MustCompile(`(?:^(?:[\d/:.,TZ+-]+\s+)*\[?|\[)(TRACE|DEBUG|INFO|NOTICE|WARN|WARNING|ERROR|FATAL|PANIC|CRITICAL)\b`)
+)
+
+// LogsPrinter is a printer to print kubectl logs output.
+// Each line is colorized by its log level, and a timestamp given by --timestamps is highlighted.
+// A prefix given by --prefix is colorized per container so that interleaved logs are distinguishable.
+// A line written as a json object is colorized by its keys and values.
+type LogsPrinter struct {
+	Theme *Theme
+	// ReformatJSON makes json logs printed as "time level msg key=value..." instead of json.
+	ReformatJSON bool
+}
+
+// kubectl logs
+// 2023-01-02T15:04:05.000000000Z I0102 15:04:05.000000       1 main.go:12] started
+// 2023-01-02T15:04:05.000000000Z {"level":"warn","msg":"slow response"}
+// 2023-01-02T15:04:05.000000000Z time=... level=error msg="failed to connect"
+// [pod/nginx-6799fc88d8-2wqxz/nginx] 2023-01-02T15:04:05.000000000Z 10.0.0.1 - - "GET / HTTP/1.1" 200
+func (lp *LogsPrinter) Print(r io.Reader, w io.Writer) {
+	// bufio.Scanner is not used because it can't read a line longer than its buffer,
+	// which is common in logs.
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			fmt.Fprintf(w, "%s\n", lp.colorizeLine(strings.TrimSuffix(line, "\n")))
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (lp *LogsPrinter) colorizeLine(line string) string {
+	prefix := logPrefix.FindString(line)
+	line = strings.TrimPrefix(line, prefix)
+
+	if prefix != "" {
+		prefix = lp.colorizePrefix(strings.TrimSuffix(prefix, " ")) + " "
+	}
+
+	timestamp := logTimestamp.FindString(line)
+	message := strings.TrimPrefix(line, timestamp)
+
+	if timestamp != "" {
+		timestamp = color.Apply(strings.TrimSuffix(timestamp, " "), lp.Theme.Timestamp) + " "
+	}
+
+	if colorized, ok := lp.colorizeJSONLog(message, lp.ReformatJSON); ok {
+		message = colorized
+	} else if c, ok := lp.colorByLogLevel(detectLogLevel(message)); ok {
+		message = color.Apply(message, c)
+	}
+
+	return prefix + timestamp + message
+}
+
+// colorizePrefix colorizes "[pod/name/container]" with a color chosen by its hash.
+func (lp *LogsPrinter) colorizePrefix(prefix string) string {
+	if len(lp.Theme.LogPrefixes) == 0 {
+		return prefix
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(prefix))
+	c := lp.Theme.LogPrefixes[h.Sum32()%uint32(len(lp.Theme.LogPrefixes))]
+
+	return color.Apply(prefix, c)
+}
+
+func (lp *LogsPrinter) colorByLogLevel(level logLevel) (color.Color, bool) {
+	switch level {
+	case logLevelDebug:
+		return lp.Theme.Debug, true
+	case logLevelInfo:
+		return lp.Theme.Info, true
+	case logLevelWarn:
+		return lp.Theme.Warning, true
+	case logLevelError:
+		return lp.Theme.Error, true
+	}
+
+	return 0, false
+}
+
+// detectLogLevel returns the log level of the given log message.
+// It understands klog, json and logfmt formats, then falls back to find a level word in upper case at a level position.
+func detectLogLevel(message string) logLevel {
+	if m := klogHeader.FindStringSubmatch(message); m != nil {
+		switch m[1] {
+		case "I":
+			return logLevelInfo
+		case "W":
+			return logLevelWarn
+		default: // E, F
+			return logLevelError
+		}
+	}
+
+	for _, re := range []*regexp.Regexp{jsonLogLevel, logfmtLogLevel, plainLogLevel} {
+		if m := re.FindStringSubmatch(message); m != nil {
+			if level, ok := logLevels[strings.ToLower(m[1])]; ok {
+				return level
+			}
+		}
+	}
+
+	return logLevelUnknown
+}
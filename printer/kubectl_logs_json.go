@@ -0,0 +1,199 @@
+package printer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/hidetatz/kubecolor/color"
+)
+
+var errNotJSONObject = errors.New("not a json object")
+
+// well-known keys in structured logs
+var (
+	jsonLogTimeKeys    = []string{"time", "ts", "timestamp", "@timestamp"}
+	jsonLogLevelKeys   = []string{"level", "lvl", "severity"}
+	jsonLogMessageKeys = []string{"msg", "message"}
+)
+
+// jsonField is a key and its raw value in a json object.
+// A json object is parsed into a slice of it to keep the order of the keys.
+type jsonField struct {
+	key   string
+	value json.RawMessage
+}
+
+// parseJSONObject parses a json object keeping the order of its keys.
+// It returns an error if data is not exactly one json object.
+func parseJSONObject(data []byte) ([]jsonField, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return nil, errNotJSONObject
+	}
+
+	fields := []jsonField{}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		key, _ := t.(string) // a key in an object is always a string
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, jsonField{key: key, value: value})
+	}
+
+	// closing "}"
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	// nothing must follow the object
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errNotJSONObject
+	}
+
+	return fields, nil
+}
+
+// colorizeJSONLog colorizes a log line written as a json object, e.g. {"level":"info","msg":"started"}.
+// When reformat is true, it is printed as "time level msg key=value..." instead of json.
+// Otherwise, colors are added to the line as it is, keeping its spacing and escapes.
+// ok is false when the message is not a json object, then it should be printed as a plain text.
+func (lp *LogsPrinter) colorizeJSONLog(message string, reformat bool) (string, bool) {
+	trimmed := strings.TrimSpace(message)
+	if !strings.HasPrefix(trimmed, "{") || !strings.HasSuffix(trimmed, "}") {
+		return "", false
+	}
+
+	fields, err := parseJSONObject([]byte(trimmed))
+	if err != nil {
+		return "", false
+	}
+
+	if reformat {
+		return lp.reformatJSONLog(fields), true
+	}
+
+	return lp.colorizeJSON(message, 0), true
+}
+
+// reformatJSONLog prints a json log in the form of "time level msg key=value...".
+// Escapes in the message are kept so that a log is printed in one line.
+func (lp *LogsPrinter) reformatJSONLog(fields []jsonField) string {
+	timestamp, fields := takeJSONField(fields, jsonLogTimeKeys)
+	level, fields := takeJSONField(fields, jsonLogLevelKeys)
+	message, fields := takeJSONField(fields, jsonLogMessageKeys)
+
+	parts := []string{}
+	if timestamp != nil {
+		parts = append(parts, color.Apply(jsonScalarString(timestamp), lp.Theme.Timestamp))
+	}
+
+	if level != nil {
+		l := strings.ToUpper(jsonScalarString(level))
+		if c, ok := lp.colorByLogLevel(logLevels[strings.ToLower(l)]); ok {
+			l = color.Apply(l, c)
+		}
+		parts = append(parts, l)
+	}
+
+	if message != nil {
+		parts = append(parts, jsonRawString(message))
+	}
+
+	for _, f := range fields {
+		var value string
+		if isJSONString(f.value) {
+			value = color.Apply(toLogfmtValue(jsonScalarString(f.value)), lp.Theme.String)
+		} else {
+			value = lp.colorizeJSON(string(f.value), 1)
+		}
+
+		parts = append(parts, fmt.Sprintf("%s=%s", color.Apply(f.key, getColorByKeyIndent(0, 1, lp.Theme)), value))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// colorizeJSON colorizes keys and values in json in a line keeping its layout.
+// depth is the depth of the json in the log object to colorize its keys.
+// The value of the log level is colorized by the level.
+func (lp *LogsPrinter) colorizeJSON(value string, depth int) string {
+	var buf bytes.Buffer
+	bw := bufio.NewWriter(&buf)
+	t := &jsonTokenizer{
+		r:         bufio.NewReader(strings.NewReader(value)),
+		w:         bw,
+		theme:     lp.Theme,
+		keyOffset: depth - 1,
+		stringColor: func(key string, d int, value string) (color.Color, bool) {
+			if depth != 0 || d != 1 || !containsAny(jsonLogLevelKeys, []string{key}) {
+				return 0, false
+			}
+			return lp.colorByLogLevel(logLevels[strings.ToLower(value)])
+		},
+	}
+	t.run()
+	bw.Flush()
+
+	// the tokenizer adds a newline at the end
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// takeJSONField finds the first field which has one of keys, then returns its value and fields without it.
+// The value is nil when no field is found.
+func takeJSONField(fields []jsonField, keys []string) (json.RawMessage, []jsonField) {
+	for i, f := range fields {
+		if containsAny(keys, []string{f.key}) {
+			rest := append(append([]jsonField{}, fields[:i]...), fields[i+1:]...)
+			return f.value, rest
+		}
+	}
+
+	return nil, fields
+}
+
+func isJSONString(value json.RawMessage) bool {
+	return len(value) > 0 && value[0] == '"'
+}
+
+// jsonScalarString returns a json string without quotes and escapes,
+// or other json values as they are.
+func jsonScalarString(value json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		return s
+	}
+
+	return string(value)
+}
+
+// jsonRawString returns a json string without quotes but with its escapes,
+// or other json values as they are.
+func jsonRawString(value json.RawMessage) string {
+	if isJSONString(value) && len(value) >= 2 {
+		return string(value[1 : len(value)-1])
+	}
+
+	return string(value)
+}
+
+// toLogfmtValue quotes s if it contains spaces, quotes, newlines or "=", so that it can be a value in logfmt.
+func toLogfmtValue(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t\r\n\"=") {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
@@ -0,0 +1,191 @@
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hidetatz/kubecolor/testutil"
+)
+
+func Test_LogsPrinter_Print(t *testing.T) {
+	tests := []struct {
+		name         string
+		theme        *Theme
+		reformatJSON bool
+		input        string
+		expected     string
+	}{
+		{
+			name:  "klog",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				I0102 15:04:05.000000       1 main.go:12] started
+				W0102 15:04:05.000000       1 main.go:13] slow response
+				E0102 15:04:05.000000       1 main.go:14] failed to connect
+				F0102 15:04:05.000000       1 main.go:15] exiting`),
+			expected: testutil.NewHereDoc(`
+				[32mI0102 15:04:05.000000       1 main.go:12] started[0m
+				[33mW0102 15:04:05.000000       1 main.go:13] slow response[0m
+				[31mE0102 15:04:05.000000       1 main.go:14] failed to connect[0m
+				[31mF0102 15:04:05.000000       1 main.go:15] exiting[0m
+			`),
+		},
+		{
+			name:  "json",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				{"level":"debug","msg":"cache hit"}
+				{"level": "INFO", "msg": "started"}
+				{"severity":"warning","msg":"slow response"}
+				{"lvl":"error","msg":"failed to connect"}`),
+			expected: testutil.NewHereDoc(`
+				{"[33mlevel[0m":"[2mdebug[0m","[33mmsg[0m":"[36mcache hit[0m"}
+				{"[33mlevel[0m": "[32mINFO[0m", "[33mmsg[0m": "[36mstarted[0m"}
+				{"[33mseverity[0m":"[33mwarning[0m","[33mmsg[0m":"[36mslow response[0m"}
+				{"[33mlvl[0m":"[31merror[0m","[33mmsg[0m":"[36mfailed to connect[0m"}
+			`),
+		},
+		{
+			name:  "json with various values",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				{"level":"info","msg":"request \"done\"","status":200,"latency":0.25,"cached":false,"user":null,"req":{"method":"GET","tags":["a",1]}}`),
+			expected: testutil.NewHereDoc(`
+				{"[33mlevel[0m":"[32minfo[0m","[33mmsg[0m":"[36mrequest \"done\"[0m","[33mstatus[0m":[35m200[0m,"[33mlatency[0m":[35m0.25[0m,"[33mcached[0m":[32mfalse[0m,"[33muser[0m":[33mnull[0m,"[33mreq[0m":{"[37mmethod[0m":"[36mGET[0m","[37mtags[0m":["[36ma[0m",[35m1[0m]}}
+			`),
+		},
+		{
+			name:  "json keeps its layout and escapes",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				{ "level" : "error", "msg":"line1\nline2 \u0026 \/path",  "err": { "code" : 1 } }`),
+			expected: testutil.NewHereDoc(`
+				{ "[33mlevel[0m" : "[31merror[0m", "[33mmsg[0m":"[36mline1\nline2 \u0026 \/path[0m",  "[33merr[0m": { "[37mcode[0m" : [35m1[0m } }
+			`),
+		},
+		{
+			name:  "broken json falls back to plain text",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				{"level":"error","msg":"truncated}
+				{"level":"error"} {"level":"info"}`),
+			expected: testutil.NewHereDoc(`
+				[31m{"level":"error","msg":"truncated}[0m
+				[31m{"level":"error"} {"level":"info"}[0m
+			`),
+		},
+		{
+			name:         "json is reformatted",
+			theme:        DarkTheme(),
+			reformatJSON: true,
+			input: testutil.NewHereDoc(`
+				{"ts":"2023-01-02T15:04:05Z","level":"warn","msg":"slow response","path":"/api","latency":1.5,"query":"a=b","req":{"method":"GET"}}
+				{"msg":"no level","user":"alice"}
+				{"level":"error","msg":"failed:\n\tat main.go","stack":"a\nb"}
+				level=error msg="not json"`),
+			expected: testutil.NewHereDoc(`
+				[34m2023-01-02T15:04:05Z[0m [33mWARN[0m slow response [33mpath[0m=[36m/api[0m [33mlatency[0m=[35m1.5[0m [33mquery[0m=[36m"a=b"[0m [33mreq[0m={"[37mmethod[0m":"[36mGET[0m"}
+				no level [33muser[0m=[36malice[0m
+				[31mERROR[0m failed:\n\tat main.go [33mstack[0m=[36m"a\nb"[0m
+				[31mlevel=error msg="not json"[0m
+			`),
+		},
+		{
+			name:  "logfmt",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				time=2023-01-02T15:04:05Z level=debug msg="cache hit"
+				time=2023-01-02T15:04:05Z level=info msg=started
+				time=2023-01-02T15:04:05Z level="warn" msg="slow response"
+				time=2023-01-02T15:04:05Z level=error msg="failed to connect"`),
+			expected: testutil.NewHereDoc(`
+				[2mtime=2023-01-02T15:04:05Z level=debug msg="cache hit"[0m
+				[32mtime=2023-01-02T15:04:05Z level=info msg=started[0m
+				[33mtime=2023-01-02T15:04:05Z level="warn" msg="slow response"[0m
+				[31mtime=2023-01-02T15:04:05Z level=error msg="failed to connect"[0m
+			`),
+		},
+		{
+			name:  "plain text",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				2023/01/02 15:04:05 [INFO] started
+				2023/01/02 15:04:05 [WARN] slow response
+				2023/01/02 15:04:05 ERROR: failed to connect
+				2023/01/02 15:04:05 something happened
+				an error occurred in lower case
+				2023-01-02 15:04:05,123 DEBUG cache hit
+				[main] [WARN] slow response
+				msg="no ERROR found"
+				10.0.0.1 - - "GET /INFO/ HTTP/1.1" 200`),
+			expected: testutil.NewHereDoc(`
+				[32m2023/01/02 15:04:05 [INFO] started[0m
+				[33m2023/01/02 15:04:05 [WARN] slow response[0m
+				[31m2023/01/02 15:04:05 ERROR: failed to connect[0m
+				2023/01/02 15:04:05 something happened
+				an error occurred in lower case
+				[2m2023-01-02 15:04:05,123 DEBUG cache hit[0m
+				[33m[main] [WARN] slow response[0m
+				msg="no ERROR found"
+				10.0.0.1 - - "GET /INFO/ HTTP/1.1" 200
+			`),
+		},
+		{
+			name:  "timestamps",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				2023-01-02T15:04:05.123456789Z I0102 15:04:05.000000       1 main.go:12] started
+				2023-01-02T15:04:05.123456789+09:00 something happened
+				2023-01-02T15:04:05Z {"level":"error","msg":"failed to connect"}`),
+			expected: testutil.NewHereDoc(`
+				[34m2023-01-02T15:04:05.123456789Z[0m [32mI0102 15:04:05.000000       1 main.go:12] started[0m
+				[34m2023-01-02T15:04:05.123456789+09:00[0m something happened
+				[34m2023-01-02T15:04:05Z[0m {"[33mlevel[0m":"[31merror[0m","[33mmsg[0m":"[36mfailed to connect[0m"}
+			`),
+		},
+		{
+			name:  "prefix has the same color per container",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				[pod/nginx-6799fc88d8-2wqxz/nginx] 10.0.0.1 - - "GET / HTTP/1.1" 200
+				[pod/nginx-6799fc88d8-8vlfk/nginx] 10.0.0.2 - - "GET / HTTP/1.1" 200
+				[pod/nginx-6799fc88d8-2wqxz/sidecar] level=warn msg="slow response"
+				[pod/nginx-6799fc88d8-2wqxz/nginx] 2023-01-02T15:04:05Z [ERROR] upstream timed out`),
+			expected: testutil.NewHereDoc(`
+				[32m[pod/nginx-6799fc88d8-2wqxz/nginx][0m 10.0.0.1 - - "GET / HTTP/1.1" 200
+				[34m[pod/nginx-6799fc88d8-8vlfk/nginx][0m 10.0.0.2 - - "GET / HTTP/1.1" 200
+				[36m[pod/nginx-6799fc88d8-2wqxz/sidecar][0m [33mlevel=warn msg="slow response"[0m
+				[32m[pod/nginx-6799fc88d8-2wqxz/nginx][0m [34m2023-01-02T15:04:05Z[0m [31m[ERROR] upstream timed out[0m
+			`),
+		},
+		{
+			name:  "light theme",
+			theme: LightTheme(),
+			input: testutil.NewHereDoc(`
+				2023-01-02T15:04:05Z level=info msg=started`),
+			expected: testutil.NewHereDoc(`
+				[34m2023-01-02T15:04:05Z[0m [32mlevel=info msg=started[0m
+			`),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := strings.NewReader(tt.input)
+			var w bytes.Buffer
+			printer := LogsPrinter{Theme: tt.theme, ReformatJSON: tt.reformatJSON}
+			printer.Print(r, &w)
+			testutil.MustEqual(t, tt.expected, w.String())
+		})
+	}
+}
+
+func Test_LogsPrinter_Print_LongLine(t *testing.T) {
+	line := "level=error msg=" + strings.Repeat("x", 1024*1024)
+	var w bytes.Buffer
+	printer := LogsPrinter{Theme: DarkTheme()}
+	printer.Print(strings.NewReader(line), &w)
+	testutil.MustEqual(t, "[31m"+line+"[0m\n", w.String())
+}
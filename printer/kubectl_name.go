@@ -0,0 +1,32 @@
+package printer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hidetatz/kubecolor/color"
+)
+
+// NamePrinter is a printer to print "-o name" output.
+type NamePrinter struct {
+	Theme *Theme
+}
+
+// kubectl get pods,deploy -o name
+// pod/nginx-6799fc88d8-dnmv5
+// deployment.apps/nginx
+func (np *NamePrinter) Print(r io.Reader, w io.Writer) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		kind, name, ok := strings.Cut(line, "/")
+		if !ok {
+			fmt.Fprintf(w, "%s\n", color.Apply(line, np.Theme.String))
+			continue
+		}
+
+		fmt.Fprintf(w, "%s/%s\n", color.Apply(kind, np.Theme.Key[0]), color.Apply(name, np.Theme.String))
+	}
+}
@@ -0,0 +1,59 @@
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hidetatz/kubecolor/testutil"
+)
+
+func Test_NamePrinter_Print(t *testing.T) {
+	tests := []struct {
+		name     string
+		theme    *Theme
+		input    string
+		expected string
+	}{
+		{
+			name:  "kind and name",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				pod/nginx
+				service/nginx`),
+			expected: testutil.NewHereDoc(`
+				[33mpod[0m/[36mnginx[0m
+				[33mservice[0m/[36mnginx[0m
+			`),
+		},
+		{
+			name:  "kind with api group",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				deployment.apps/nginx`),
+			expected: testutil.NewHereDoc(`
+				[33mdeployment.apps[0m/[36mnginx[0m
+			`),
+		},
+		{
+			name:  "without kind",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				nginx`),
+			expected: testutil.NewHereDoc(`
+				[36mnginx[0m
+			`),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := strings.NewReader(tt.input)
+			var w bytes.Buffer
+			printer := NamePrinter{Theme: tt.theme}
+			printer.Print(r, &w)
+			testutil.MustEqual(t, tt.expected, w.String())
+		})
+	}
+}
@@ -2,7 +2,6 @@ package printer
 
 import (
 	"io"
-	"strconv"
 	"strings"
 
 	"github.com/hidetatz/kubecolor/color"
@@ -13,99 +12,305 @@ import (
 // which kubectl subcommand is executed.
 type KubectlOutputColoredPrinter struct {
 	SubcommandInfo *kubectl.SubcommandInfo
-	DarkBackground bool
+	Theme          *Theme
 	Recursive      bool
+	// ReformatJSONLogs makes json logs printed as "time level msg key=value..." in kubectl logs
+	ReformatJSONLogs bool
+	// RestartsThresholds is thresholds to colorize RESTARTS in kubectl get. DefaultRestartsThresholds is used when it's zero.
+	RestartsThresholds Thresholds
+	// CPUThresholds and MemoryThresholds is thresholds to colorize CPU% and MEMORY% in kubectl top.
+	// DefaultCPUThresholds and DefaultMemoryThresholds are used when they are zero.
+	CPUThresholds    Thresholds
+	MemoryThresholds Thresholds
+	// Registry is rules to choose a printer for the subcommand. The built-in rules are used when it's nil.
+	Registry *Registry
 }
 
 // Print reads r then write it to w, its format is based on kubectl subcommand.
-// If given subcommand is not supported by the printer, it prints data in Green.
+// The printer is chosen by Registry. If no printer is found, it prints data in the default color of the theme.
 func (kp *KubectlOutputColoredPrinter) Print(r io.Reader, w io.Writer) {
-	withHeader := !kp.SubcommandInfo.NoHeader
-
-	var printer Printer = &SingleColoredPrinter{Color: color.Green} // default in green
-
-	switch kp.SubcommandInfo.Subcommand {
-	case kubectl.Top, kubectl.APIResources:
-		printer = NewTablePrinter(withHeader, kp.DarkBackground, nil)
-
-	case kubectl.APIVersions:
-		printer = NewTablePrinter(false, kp.DarkBackground, nil) // api-versions always doesn't have header
-
-	case kubectl.Get:
-		switch {
-		case kp.SubcommandInfo.FormatOption == kubectl.None, kp.SubcommandInfo.FormatOption == kubectl.Wide:
-			printer = NewTablePrinter(
-				withHeader,
-				kp.DarkBackground,
-				func(_ int, column string) (color.Color, bool) {
-					if column == "CrashLoopBackOff" {
-						return color.Red, true
-					}
-
-					// When Readiness is "n/m" then yellow
-					if strings.Count(column, "/") == 1 {
-						if arr := strings.Split(column, "/"); arr[0] != arr[1] {
-							_, e1 := strconv.Atoi(arr[0])
-							_, e2 := strconv.Atoi(arr[1])
-							if e1 == nil && e2 == nil { // check both is number
-								return color.Yellow, true
-							}
+	registry := kp.Registry
+	if registry == nil {
+		registry = builtinRegistry
+	}
+
+	printer := registry.Lookup(kp)
+	if printer == nil {
+		printer = &SingleColoredPrinter{Color: kp.Theme.Default}
+	}
+
+	printer.Print(r, w)
+}
+
+// withHeader returns if the output has a table header.
+func (kp *KubectlOutputColoredPrinter) withHeader() bool {
+	return !kp.SubcommandInfo.NoHeader
+}
+
+// getColorDecider returns a color decider for tables in kubectl get.
+func (kp *KubectlOutputColoredPrinter) getColorDecider(resources []string) func(header, column string) (color.Color, bool) {
+	return newGetColorDecider(resources, kp.Theme, thresholdsOrDefault(kp.RestartsThresholds, DefaultRestartsThresholds))
+}
+
+// builtinRegistry is DefaultRegistry shared by printers which don't have their own registry.
+var builtinRegistry = DefaultRegistry()
+
+// unsupportedCommands is commands which are not colorized by default because they are interactive or print
+// nothing to colorize. They can be enabled in the config file.
+var unsupportedCommands = []string{
+	"create", "debug", "delete", "edit", "attach", "replace", "completion", "exec", "proxy", "plugin", "wait", "run",
+	// plugins by kubectx, which can be interactive
+	"ctx", "ns",
+}
+
+// jsonYamlCommands is commands which print json or yaml by -o json or -o yaml.
+var jsonYamlCommands = []string{
+	"get", "events", "version", "auth whoami", "rollout",
+	"apply", "scale", "label", "annotate", "patch", "cordon", "uncordon",
+	"drain", "set", "expose", "taint", "autoscale", "certificate",
+}
+
+// singleColoredCommands is commands which print messages in the default color.
+// They are not printed by AutoPrinter because they print little and can run for long, e.g. port-forward.
+var singleColoredCommands = []string{"port-forward", "cp", "cluster-info"}
+
+// actionCommands is commands which print results of actions, e.g. "deployment.apps/nginx created".
+var actionCommands = []string{
+	"rollout", "apply", "scale", "label", "annotate", "patch", "cordon", "uncordon",
+	"drain", "set", "expose", "taint", "autoscale", "certificate",
+}
+
+// DefaultRegistry returns a new registry which has the built-in rules of kubecolor.
+// Rules can be added to it to override or extend them.
+func DefaultRegistry() *Registry {
+	r := NewRegistry()
+
+	// the output of subcommands which have no specific printer is printed by guessing its format
+	r.Register(Rule{
+		Priority: PriorityFallback,
+		New:      func(kp *KubectlOutputColoredPrinter) Printer { return &AutoPrinter{Theme: kp.Theme} },
+	})
+
+	r.Register(
+		Rule{
+			Command:  "top",
+			Priority: PrioritySubcommand,
+			New: func(kp *KubectlOutputColoredPrinter) Printer {
+				return &TopPrinter{
+					WithHeader:       kp.withHeader(),
+					Theme:            kp.Theme,
+					CPUThresholds:    thresholdsOrDefault(kp.CPUThresholds, DefaultCPUThresholds),
+					MemoryThresholds: thresholdsOrDefault(kp.MemoryThresholds, DefaultMemoryThresholds),
+				}
+			},
+		},
+		Rule{
+			Command:  "api-resources",
+			Priority: PrioritySubcommand,
+			New:      func(kp *KubectlOutputColoredPrinter) Printer { return NewTablePrinter(kp.withHeader(), kp.Theme, nil) },
+		},
+		Rule{
+			Command:  "api-versions",
+			Priority: PrioritySubcommand,
+			// api-versions always doesn't have header
+			New: func(kp *KubectlOutputColoredPrinter) Printer { return NewTablePrinter(false, kp.Theme, nil) },
+		},
+		Rule{
+			Command:  "get",
+			Formats:  []kubectl.FormatOption{kubectl.None, kubectl.Wide},
+			Priority: PrioritySubcommand,
+			New: func(kp *KubectlOutputColoredPrinter) Printer {
+				return NewTablePrinter(kp.withHeader(), kp.Theme, kp.getColorDecider(kp.SubcommandInfo.Resources))
+			},
+		},
+		Rule{
+			Command:  "events",
+			Priority: PrioritySubcommand,
+			New: func(kp *KubectlOutputColoredPrinter) Printer {
+				return NewTablePrinter(kp.withHeader(), kp.Theme, kp.getColorDecider([]string{"events"}))
+			},
+		},
+		Rule{
+			Command:  "describe",
+			Priority: PrioritySubcommand,
+			New: func(kp *KubectlOutputColoredPrinter) Printer {
+				return &DescribePrinter{
+					Theme:        kp.Theme,
+					TablePrinter: NewTablePrinter(false, kp.Theme, nil),
+				}
+			},
+		},
+		Rule{
+			Command:  "explain",
+			Priority: PrioritySubcommand,
+			New: func(kp *KubectlOutputColoredPrinter) Printer {
+				return &ExplainPrinter{
+					Theme:     kp.Theme,
+					Recursive: kp.Recursive,
+				}
+			},
+		},
+		Rule{
+			Command:  "version",
+			Priority: PrioritySubcommand,
+			New:      func(kp *KubectlOutputColoredPrinter) Printer { return &VersionPrinter{Theme: kp.Theme} },
+		},
+		Rule{
+			Command:  "version",
+			Match:    func(info *kubectl.SubcommandInfo) bool { return info.Short },
+			Priority: PrioritySubcommand + 1,
+			New:      func(kp *KubectlOutputColoredPrinter) Printer { return &VersionShortPrinter{Theme: kp.Theme} },
+		},
+		Rule{
+			Command:  "diff",
+			Priority: PrioritySubcommand,
+			New:      func(kp *KubectlOutputColoredPrinter) Printer { return &DiffPrinter{Theme: kp.Theme} },
+		},
+		Rule{
+			Command:  "logs",
+			Priority: PrioritySubcommand,
+			New: func(kp *KubectlOutputColoredPrinter) Printer {
+				return &LogsPrinter{
+					Theme:        kp.Theme,
+					ReformatJSON: kp.ReformatJSONLogs,
+				}
+			},
+		},
+		Rule{
+			Command:  "options",
+			Priority: PrioritySubcommand,
+			New:      func(kp *KubectlOutputColoredPrinter) Printer { return &OptionsPrinter{Theme: kp.Theme} },
+		},
+		Rule{
+			Command:  "config view",
+			Priority: PrioritySubcommand,
+			// config view prints yaml by default
+			New: func(kp *KubectlOutputColoredPrinter) Printer { return &YamlPrinter{Theme: kp.Theme} },
+		},
+		Rule{
+			Command:  "config view",
+			Formats:  []kubectl.FormatOption{kubectl.Json},
+			Priority: PriorityFormat,
+			New:      func(kp *KubectlOutputColoredPrinter) Printer { return &JsonPrinter{Theme: kp.Theme} },
+		},
+		Rule{
+			Command:  "auth can-i",
+			Priority: PrioritySubcommand,
+			New:      func(kp *KubectlOutputColoredPrinter) Printer { return &AuthCanIPrinter{Theme: kp.Theme} },
+		},
+		Rule{
+			Command:  "auth whoami",
+			Priority: PrioritySubcommand,
+			New:      func(kp *KubectlOutputColoredPrinter) Printer { return NewTablePrinter(kp.withHeader(), kp.Theme, nil) },
+		},
+		Rule{
+			Command:  "rollout history",
+			Priority: PrioritySubcommand,
+			// the first line is the resource name, so the header is found by its letter case
+			New: func(kp *KubectlOutputColoredPrinter) Printer { return NewTablePrinter(false, kp.Theme, nil) },
+		},
+		Rule{
+			Command:  "rollout status",
+			Priority: PrioritySubcommand,
+			New: func(kp *KubectlOutputColoredPrinter) Printer {
+				return &WithFuncPrinter{
+					Fn: func(line string) color.Color {
+						if strings.HasSuffix(line, "successfully rolled out") {
+							return kp.Theme.Success
 						}
+						return kp.Theme.Warning
+					},
+				}
+			},
+		},
+	)
 
-					}
-
-					return 0, false
-				},
-			)
-		case kp.SubcommandInfo.FormatOption == kubectl.Json:
-			printer = &JsonPrinter{DarkBackground: kp.DarkBackground}
-		case kp.SubcommandInfo.FormatOption == kubectl.Yaml:
-			printer = &YamlPrinter{DarkBackground: kp.DarkBackground}
-		}
-
-	case kubectl.Describe:
-		printer = &DescribePrinter{
-			DarkBackground: kp.DarkBackground,
-			TablePrinter:   NewTablePrinter(false, kp.DarkBackground, nil),
-		}
-	case kubectl.Explain:
-		printer = &ExplainPrinter{
-			DarkBackground: kp.DarkBackground,
-			Recursive:      kp.Recursive,
-		}
-	case kubectl.Version:
-		switch {
-		case kp.SubcommandInfo.FormatOption == kubectl.Json:
-			printer = &JsonPrinter{DarkBackground: kp.DarkBackground}
-		case kp.SubcommandInfo.FormatOption == kubectl.Yaml:
-			printer = &YamlPrinter{DarkBackground: kp.DarkBackground}
-		case kp.SubcommandInfo.Short:
-			printer = &VersionShortPrinter{
-				DarkBackground: kp.DarkBackground,
-			}
-		default:
-			printer = &VersionPrinter{
-				DarkBackground: kp.DarkBackground,
-			}
-		}
-	case kubectl.Options:
-		printer = &OptionsPrinter{
-			DarkBackground: kp.DarkBackground,
-		}
-	case kubectl.Apply:
-		switch {
-		case kp.SubcommandInfo.FormatOption == kubectl.Json:
-			printer = &JsonPrinter{DarkBackground: kp.DarkBackground}
-		case kp.SubcommandInfo.FormatOption == kubectl.Yaml:
-			printer = &YamlPrinter{DarkBackground: kp.DarkBackground}
-		default:
-			printer = &ApplyPrinter{DarkBackground: kp.DarkBackground}
-		}
+	for _, command := range singleColoredCommands {
+		r.Register(Rule{
+			Command:  command,
+			Priority: PrioritySubcommand,
+			New:      func(kp *KubectlOutputColoredPrinter) Printer { return &SingleColoredPrinter{Color: kp.Theme.Default} },
+		})
 	}
 
-	if kp.SubcommandInfo.Help {
-		printer = &SingleColoredPrinter{Color: color.Yellow}
+	// cluster-info dump prints json or yaml by -o
+	r.Register(Rule{
+		Command:  "cluster-info dump",
+		Priority: PrioritySubcommand,
+		New:      func(kp *KubectlOutputColoredPrinter) Printer { return &AutoPrinter{Theme: kp.Theme} },
+	})
+
+	for _, command := range []string{"config get-contexts", "config get-clusters", "config get-users"} {
+		r.Register(Rule{
+			Command:  command,
+			Priority: PrioritySubcommand,
+			New:      func(kp *KubectlOutputColoredPrinter) Printer { return NewTablePrinter(kp.withHeader(), kp.Theme, nil) },
+		})
 	}
 
-	printer.Print(r, w)
+	for _, command := range actionCommands {
+		r.Register(Rule{
+			Command:  command,
+			Priority: PrioritySubcommand,
+			New:      func(kp *KubectlOutputColoredPrinter) Printer { return &ActionPrinter{Theme: kp.Theme} },
+		})
+	}
+
+	for _, command := range jsonYamlCommands {
+		r.Register(
+			Rule{
+				Command:  command,
+				Formats:  []kubectl.FormatOption{kubectl.Json},
+				Priority: PriorityFormat,
+				New:      func(kp *KubectlOutputColoredPrinter) Printer { return &JsonPrinter{Theme: kp.Theme} },
+			},
+			Rule{
+				Command:  command,
+				Formats:  []kubectl.FormatOption{kubectl.Yaml},
+				Priority: PriorityFormat,
+				New:      func(kp *KubectlOutputColoredPrinter) Printer { return &YamlPrinter{Theme: kp.Theme} },
+			},
+		)
+	}
+
+	// these formats are printed in the same way regardless of the subcommand
+	r.Register(
+		Rule{
+			Formats:  []kubectl.FormatOption{kubectl.Name},
+			Priority: PriorityOutputFormat,
+			New:      func(kp *KubectlOutputColoredPrinter) Printer { return &NamePrinter{Theme: kp.Theme} },
+		},
+		Rule{
+			Formats:  []kubectl.FormatOption{kubectl.CustomColumns, kubectl.CustomColumnsFile},
+			Priority: PriorityOutputFormat,
+			New: func(kp *KubectlOutputColoredPrinter) Printer {
+				return NewTablePrinter(kp.withHeader(), kp.Theme, kp.getColorDecider(kp.SubcommandInfo.Resources))
+			},
+		},
+		Rule{
+			Formats:  []kubectl.FormatOption{kubectl.JsonPath, kubectl.GoTemplate},
+			Priority: PriorityOutputFormat,
+			New:      func(kp *KubectlOutputColoredPrinter) Printer { return &TemplatePrinter{Theme: kp.Theme} },
+		},
+	)
+
+	// a plugin is not colorized by default because it can be interactive (e.g. fzf in kubectl ctx).
+	// It is colorized only when a printer is configured for it by NewPluginRule.
+	r.Register(Rule{
+		Match:    func(info *kubectl.SubcommandInfo) bool { return info.IsKrew },
+		Priority: PriorityPlugin,
+	})
+
+	// help of a plugin is left to the plugin rules because a plugin can have its own -h flag (e.g. for a host)
+	r.Register(Rule{
+		Match:    func(info *kubectl.SubcommandInfo) bool { return info.Help && !info.IsKrew },
+		Priority: PriorityHelp,
+		New:      func(kp *KubectlOutputColoredPrinter) Printer { return &SingleColoredPrinter{Color: kp.Theme.Help} },
+	})
+
+	for _, command := range unsupportedCommands {
+		r.Register(Rule{Command: command, Priority: PriorityUnsupported})
+	}
+
+	return r
 }
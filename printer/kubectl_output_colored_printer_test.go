@@ -12,14 +12,15 @@ import (
 func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 	tests := []struct {
 		name           string
-		darkBackground bool
+		theme          *Theme
 		subcommandInfo *kubectl.SubcommandInfo
+		pluginPrinter  string
 		input          string
 		expected       string
 	}{
 		{
-			name:           "kubectl top pod",
-			darkBackground: true,
+			name:  "kubectl top pod",
+			theme: DarkTheme(),
 			subcommandInfo: &kubectl.SubcommandInfo{
 				Subcommand: kubectl.Top,
 			},
@@ -29,15 +30,15 @@ func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 				app-2hhr6   1036m        220Mi
 				app-52mbv   881m         137Mi`),
 			expected: testutil.NewHereDoc(`
-				[37mNAME        CPU(cores)   MEMORY(bytes)[0m
-				[36mapp-29twd[0m   [32m779m[0m         [35m221Mi[0m
-				[36mapp-2hhr6[0m   [32m1036m[0m        [35m220Mi[0m
-				[36mapp-52mbv[0m   [32m881m[0m         [35m137Mi[0m
+				[1;37mNAME        CPU(cores)   MEMORY(bytes)[0m
+				[36mapp-29twd[0m   [33m779m[0m         [31m221Mi[0m
+				[36mapp-2hhr6[0m   [31m1036m[0m        [31m220Mi[0m
+				[36mapp-52mbv[0m   [31m881m[0m         [33m137Mi[0m
 			`),
 		},
 		{
-			name:           "kubectl top pod --no-headers",
-			darkBackground: true,
+			name:  "kubectl top pod --no-headers",
+			theme: DarkTheme(),
 			subcommandInfo: &kubectl.SubcommandInfo{
 				Subcommand: kubectl.Top,
 				NoHeader:   true,
@@ -53,8 +54,8 @@ func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 			`),
 		},
 		{
-			name:           "kubectl api-resources",
-			darkBackground: true,
+			name:  "kubectl api-resources",
+			theme: DarkTheme(),
 			subcommandInfo: &kubectl.SubcommandInfo{
 				Subcommand: kubectl.APIResources,
 			},
@@ -77,7 +78,7 @@ func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 				tokenreviews                                   authentication.k8s.io          false        TokenReview
 			`),
 			expected: testutil.NewHereDoc(`
-				[37mNAME                              SHORTNAMES   APIGROUP                       NAMESPACED   KIND[0m
+				[1;37mNAME                              SHORTNAMES   APIGROUP                       NAMESPACED   KIND[0m
 				[36mbindings[0m                                                                      [32mtrue[0m         [35mBinding[0m
 				[36mcomponentstatuses[0m                 [37mcs[0m                                          [32mfalse[0m        [35mComponentStatus[0m
 				[36mpods[0m                              [37mpo[0m                                          [32mtrue[0m         [35mPod[0m
@@ -96,8 +97,8 @@ func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 			`),
 		},
 		{
-			name:           "kubectl api-resources --no-headers",
-			darkBackground: true,
+			name:  "kubectl api-resources --no-headers",
+			theme: DarkTheme(),
 			subcommandInfo: &kubectl.SubcommandInfo{
 				Subcommand: kubectl.APIResources,
 				NoHeader:   true,
@@ -138,8 +139,8 @@ func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 			`),
 		},
 		{
-			name:           "kubectl get pod",
-			darkBackground: true,
+			name:  "kubectl get pod",
+			theme: DarkTheme(),
 			subcommandInfo: &kubectl.SubcommandInfo{
 				Subcommand: kubectl.Get,
 			},
@@ -149,15 +150,15 @@ func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 				nginx-m8pbc   1/1     Running   0          6d6h
 				nginx-qdf9b   1/1     Running   0          6d6h`),
 			expected: testutil.NewHereDoc(`
-				[37mNAME          READY   STATUS    RESTARTS   AGE[0m
-				[36mnginx-dnmv5[0m   [32m1/1[0m     [35mRunning[0m   [37m0[0m          [33m6d6h[0m
-				[36mnginx-m8pbc[0m   [32m1/1[0m     [35mRunning[0m   [37m0[0m          [33m6d6h[0m
-				[36mnginx-qdf9b[0m   [32m1/1[0m     [35mRunning[0m   [37m0[0m          [33m6d6h[0m
+				[1;37mNAME          READY   STATUS    RESTARTS   AGE[0m
+				[36mnginx-dnmv5[0m   [32m1/1[0m     [32mRunning[0m   [37m0[0m          [33m6d6h[0m
+				[36mnginx-m8pbc[0m   [32m1/1[0m     [32mRunning[0m   [37m0[0m          [33m6d6h[0m
+				[36mnginx-qdf9b[0m   [32m1/1[0m     [32mRunning[0m   [37m0[0m          [33m6d6h[0m
 			`),
 		},
 		{
-			name:           "kubectl get pod",
-			darkBackground: true,
+			name:  "kubectl get pod",
+			theme: DarkTheme(),
 			subcommandInfo: &kubectl.SubcommandInfo{
 				Subcommand: kubectl.Get,
 			},
@@ -167,15 +168,15 @@ func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 				nginx-m8pbc   1/1     Running            0          6d6h
 				nginx-qdf9b   0/1     Running            0          6d6h`),
 			expected: testutil.NewHereDoc(`
-				[37mNAME          READY   STATUS             RESTARTS   AGE[0m
+				[1;37mNAME          READY   STATUS             RESTARTS   AGE[0m
 				[36mnginx-dnmv5[0m   [32m1/1[0m     [31mCrashLoopBackOff[0m   [37m0[0m          [33m6d6h[0m
-				[36mnginx-m8pbc[0m   [32m1/1[0m     [35mRunning[0m            [37m0[0m          [33m6d6h[0m
-				[36mnginx-qdf9b[0m   [33m0/1[0m     [35mRunning[0m            [37m0[0m          [33m6d6h[0m
+				[36mnginx-m8pbc[0m   [32m1/1[0m     [32mRunning[0m            [37m0[0m          [33m6d6h[0m
+				[36mnginx-qdf9b[0m   [33m0/1[0m     [32mRunning[0m            [37m0[0m          [33m6d6h[0m
 			`),
 		},
 		{
-			name:           "kubectl get pod --no-headers",
-			darkBackground: true,
+			name:  "kubectl get pod --no-headers",
+			theme: DarkTheme(),
 			subcommandInfo: &kubectl.SubcommandInfo{
 				Subcommand: kubectl.Get,
 				NoHeader:   true,
@@ -185,14 +186,109 @@ func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 				nginx-m8pbc   1/1     Running   0          6d6h
 				nginx-qdf9b   1/1     Running   0          6d6h`),
 			expected: testutil.NewHereDoc(`
-				[36mnginx-dnmv5[0m   [32m1/1[0m     [35mRunning[0m   [37m0[0m          [33m6d6h[0m
-				[36mnginx-m8pbc[0m   [32m1/1[0m     [35mRunning[0m   [37m0[0m          [33m6d6h[0m
-				[36mnginx-qdf9b[0m   [32m1/1[0m     [35mRunning[0m   [37m0[0m          [33m6d6h[0m
+				[36mnginx-dnmv5[0m   [32m1/1[0m     [32mRunning[0m   [37m0[0m          [33m6d6h[0m
+				[36mnginx-m8pbc[0m   [32m1/1[0m     [32mRunning[0m   [37m0[0m          [33m6d6h[0m
+				[36mnginx-qdf9b[0m   [32m1/1[0m     [32mRunning[0m   [37m0[0m          [33m6d6h[0m
+			`),
+		},
+		{
+			name:  "kubectl get pod,node, statuses are colored by its meaning",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand: kubectl.Get,
+			},
+			input: testutil.NewHereDoc(`
+				NAME              READY   STATUS              RESTARTS   AGE
+				pod/nginx-dnmv5   0/1     ImagePullBackOff    0          6d6h
+				pod/nginx-m8pbc   0/1     ContainerCreating   0          6d6h
+				pod/nginx-qdf9b   0/1     Init:0/1            0          6d6h
+				pod/nginx-xg5kw   0/1     Completed           0          6d6h
+
+				NAME          STATUS                        ROLES           AGE   VERSION
+				node/node-1   Ready                         control-plane   19d   v1.25.3
+				node/node-2   NotReady,SchedulingDisabled   <none>          19d   v1.25.3`),
+			expected: testutil.NewHereDoc(`
+				[1;37mNAME              READY   STATUS              RESTARTS   AGE[0m
+				[36mpod/nginx-dnmv5[0m   [33m0/1[0m     [31mImagePullBackOff[0m    [37m0[0m          [33m6d6h[0m
+				[36mpod/nginx-m8pbc[0m   [33m0/1[0m     [33mContainerCreating[0m   [37m0[0m          [33m6d6h[0m
+				[36mpod/nginx-qdf9b[0m   [33m0/1[0m     [33mInit:0/1[0m            [37m0[0m          [33m6d6h[0m
+				[36mpod/nginx-xg5kw[0m   [33m0/1[0m     [32mCompleted[0m           [37m0[0m          [33m6d6h[0m
+				[1;37m[0m
+				[1;37mNAME          STATUS                        ROLES           AGE   VERSION[0m
+				[36mnode/node-1[0m   [32mReady[0m                         [36mcontrol-plane[0m   [33m19d[0m   [32mv1.25.3[0m
+				[36mnode/node-2[0m   [31mNotReady,SchedulingDisabled[0m   [2m<none>[0m          [33m19d[0m   [32mv1.25.3[0m
+			`),
+		},
+		{
+			name:  "kubectl get jobs, completions are colored",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand: kubectl.Get,
+				Resources:  []string{"jobs"},
+			},
+			input: testutil.NewHereDoc(`
+				NAME           STATUS     COMPLETIONS   DURATION   AGE
+				pi             Complete   1/1           8s         2m
+				pi-slow        Running    0/1           2m         2m`),
+			expected: testutil.NewHereDoc(`
+				[1;37mNAME           STATUS     COMPLETIONS   DURATION   AGE[0m
+				[36mpi[0m             [32mComplete[0m   [35m1/1[0m           [37m8s[0m         [1;32m2m[0m
+				[36mpi-slow[0m        [32mRunning[0m    [33m0/1[0m           [37m2m[0m         [1;32m2m[0m
+			`),
+		},
+		{
+			name:  "kubectl get configmaps --no-headers, names are not colored as statuses",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand: kubectl.Get,
+				NoHeader:   true,
+				Resources:  []string{"configmaps"},
+			},
+			input: testutil.NewHereDoc(`
+				Pending   1      3d
+				ready-2   2/3    3d`),
+			expected: testutil.NewHereDoc(`
+				[36mPending[0m   [32m1[0m      [35m3d[0m
+				[36mready-2[0m   [32m2/3[0m    [35m3d[0m
+			`),
+		},
+		{
+			name:  "kubectl get events, warnings and failure reasons are colored",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand: kubectl.Get,
+				Resources:  []string{"events"},
+			},
+			input: testutil.NewHereDoc(`
+				LAST SEEN   TYPE      REASON             OBJECT          MESSAGE
+				2m          Normal    Scheduled          pod/nginx       Successfully assigned default/nginx to node-1
+				1m          Warning   BackOff            pod/nginx       Back-off restarting failed container
+				30s         Warning   FailedScheduling   pod/redis       0/1 nodes are available: 1 Insufficient cpu.`),
+			expected: testutil.NewHereDoc(`
+				[1;37mLAST SEEN   TYPE      REASON             OBJECT          MESSAGE[0m
+				[1;32m2m[0m          [2mNormal[0m    [35mScheduled[0m          [37mpod/nginx[0m       [33mSuccessfully assigned default/nginx to node-1[0m
+				[1;32m1m[0m          [33mWarning[0m   [31mBackOff[0m            [37mpod/nginx[0m       [33mBack-off restarting failed container[0m
+				[1;32m30s[0m         [33mWarning[0m   [31mFailedScheduling[0m   [37mpod/redis[0m       [33m0/1 nodes are available: 1 Insufficient cpu.[0m
 			`),
 		},
 		{
-			name:           "kubectl get pod -o wide",
-			darkBackground: true,
+			name:  "kubectl events --no-headers",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand: kubectl.Events,
+				NoHeader:   true,
+			},
+			input: testutil.NewHereDoc(`
+				1m          Warning   BackOff            pod/nginx       Back-off restarting failed container
+				2m          Normal    Pulled             pod/nginx       Container image "nginx" already present`),
+			expected: testutil.NewHereDoc(`
+				[36m1m[0m          [33mWarning[0m   [31mBackOff[0m            [37mpod/nginx[0m       [33mBack-off restarting failed container[0m
+				[36m2m[0m          [2mNormal[0m    [35mPulled[0m             [37mpod/nginx[0m       [33mContainer image "nginx" already present[0m
+			`),
+		},
+		{
+			name:  "kubectl get pod -o wide",
+			theme: DarkTheme(),
 			subcommandInfo: &kubectl.SubcommandInfo{
 				Subcommand:   kubectl.Get,
 				FormatOption: kubectl.Wide,
@@ -203,15 +299,15 @@ func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 				nginx-6799fc88d8-m8pbc   1/1     Running   0          7d10h   172.18.0.4   minikube   <none>           <none>
 				nginx-6799fc88d8-qdf9b   1/1     Running   0          7d10h   172.18.0.3   minikube   <none>           <none>`),
 			expected: testutil.NewHereDoc(`
-				[37mNAME                     READY   STATUS    RESTARTS   AGE     IP           NODE       NOMINATED NODE   READINESS GATES[0m
-				[36mnginx-6799fc88d8-dnmv5[0m   [32m1/1[0m     [35mRunning[0m   [37m0[0m          [33m7d10h[0m   [36m172.18.0.5[0m   [32mminikube[0m   [35m<none>[0m           [37m<none>[0m
-				[36mnginx-6799fc88d8-m8pbc[0m   [32m1/1[0m     [35mRunning[0m   [37m0[0m          [33m7d10h[0m   [36m172.18.0.4[0m   [32mminikube[0m   [35m<none>[0m           [37m<none>[0m
-				[36mnginx-6799fc88d8-qdf9b[0m   [32m1/1[0m     [35mRunning[0m   [37m0[0m          [33m7d10h[0m   [36m172.18.0.3[0m   [32mminikube[0m   [35m<none>[0m           [37m<none>[0m
+				[1;37mNAME                     READY   STATUS    RESTARTS   AGE     IP           NODE       NOMINATED NODE   READINESS GATES[0m
+				[36mnginx-6799fc88d8-dnmv5[0m   [32m1/1[0m     [32mRunning[0m   [37m0[0m          [33m7d10h[0m   [36m172.18.0.5[0m   [32mminikube[0m   [2m<none>[0m           [2m<none>[0m
+				[36mnginx-6799fc88d8-m8pbc[0m   [32m1/1[0m     [32mRunning[0m   [37m0[0m          [33m7d10h[0m   [36m172.18.0.4[0m   [32mminikube[0m   [2m<none>[0m           [2m<none>[0m
+				[36mnginx-6799fc88d8-qdf9b[0m   [32m1/1[0m     [32mRunning[0m   [37m0[0m          [33m7d10h[0m   [36m172.18.0.3[0m   [32mminikube[0m   [2m<none>[0m           [2m<none>[0m
 			`),
 		},
 		{
-			name:           "kubectl get pod -o json",
-			darkBackground: true,
+			name:  "kubectl get pod -o json",
+			theme: DarkTheme(),
 			subcommandInfo: &kubectl.SubcommandInfo{
 				Subcommand:   kubectl.Get,
 				FormatOption: kubectl.Json,
@@ -235,8 +331,8 @@ func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 			`),
 		},
 		{
-			name:           "kubectl get pod -o yaml",
-			darkBackground: true,
+			name:  "kubectl get pod -o yaml",
+			theme: DarkTheme(),
 			subcommandInfo: &kubectl.SubcommandInfo{
 				Subcommand:   kubectl.Get,
 				FormatOption: kubectl.Yaml,
@@ -252,14 +348,14 @@ func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 				[33mapiVersion[0m: [36mv1[0m
 				[33mkind[0m: "[36mPod[0m"
 				[33mnum[0m: [35m415[0m
-				[33munknown[0m: [33m<unknown>[0m
-				[33mnone[0m: [33m<none>[0m
+				[33munknown[0m: [2m<unknown>[0m
+				[33mnone[0m: [2m<none>[0m
 				[33mbool[0m: [32mtrue[0m
 			`),
 		},
 		{
-			name:           "kubectl describe pod",
-			darkBackground: true,
+			name:  "kubectl describe pod",
+			theme: DarkTheme(),
 			subcommandInfo: &kubectl.SubcommandInfo{
 				Subcommand: kubectl.Describe,
 			},
@@ -280,12 +376,12 @@ func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 				[33mReady[0m:        [32mtrue[0m
 				[33mStart Time[0m:   [36mSat, 10 Oct 2020 14:07:17 +0900[0m
 				[33mLabels[0m:       [36mapp=nginx[0m
-				[33mAnnotations[0m:  [33m<none>[0m
+				[33mAnnotations[0m:  [2m<none>[0m
 			`),
 		},
 		{
-			name:           "kubectl api-versions",
-			darkBackground: true,
+			name:  "kubectl api-versions",
+			theme: DarkTheme(),
 			subcommandInfo: &kubectl.SubcommandInfo{
 				Subcommand: kubectl.APIVersions,
 			},
@@ -328,8 +424,8 @@ func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 			`),
 		},
 		{
-			name:           "kubectl explain",
-			darkBackground: true,
+			name:  "kubectl explain",
+			theme: DarkTheme(),
 			subcommandInfo: &kubectl.SubcommandInfo{
 				Subcommand: kubectl.Explain,
 			},
@@ -365,8 +461,8 @@ func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 			`),
 		},
 		{
-			name:           "kubectl version",
-			darkBackground: true,
+			name:  "kubectl version",
+			theme: DarkTheme(),
 			subcommandInfo: &kubectl.SubcommandInfo{
 				Subcommand: kubectl.Version,
 			},
@@ -379,8 +475,8 @@ func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 			`),
 		},
 		{
-			name:           "kubectl version --client",
-			darkBackground: true,
+			name:  "kubectl version --client",
+			theme: DarkTheme(),
 			subcommandInfo: &kubectl.SubcommandInfo{
 				Subcommand: kubectl.Version,
 			},
@@ -391,8 +487,8 @@ func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 			`),
 		},
 		{
-			name:           "kubectl version --short",
-			darkBackground: true,
+			name:  "kubectl version --short",
+			theme: DarkTheme(),
 			subcommandInfo: &kubectl.SubcommandInfo{
 				Subcommand: kubectl.Version,
 				Short:      true,
@@ -406,8 +502,8 @@ func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 			`),
 		},
 		{
-			name:           "kubectl version --short --client",
-			darkBackground: true,
+			name:  "kubectl version --short --client",
+			theme: DarkTheme(),
 			subcommandInfo: &kubectl.SubcommandInfo{
 				Subcommand: kubectl.Version,
 				Short:      true,
@@ -419,8 +515,40 @@ func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 			`),
 		},
 		{
-			name:           "kubectl options",
-			darkBackground: true,
+			name:  "kubectl diff",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand: kubectl.Diff,
+			},
+			input: testutil.NewHereDoc(`
+				@@ -1,2 +1,2 @@
+				 spec:
+				-  replicas: 3
+				+  replicas: 5`),
+			expected: testutil.NewHereDoc(`
+				[36m@@ -1,2 +1,2 @@[0m
+				 spec:
+				[31m-[0m[31m  replicas: [0m[7;31m3[0m
+				[32m+[0m[32m  replicas: [0m[7;32m5[0m
+			`),
+		},
+		{
+			name:  "kubectl logs",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand: kubectl.Logs,
+			},
+			input: testutil.NewHereDoc(`
+				I0102 15:04:05.000000       1 main.go:12] started
+				E0102 15:04:05.000000       1 main.go:14] failed to connect`),
+			expected: testutil.NewHereDoc(`
+				[32mI0102 15:04:05.000000       1 main.go:12] started[0m
+				[31mE0102 15:04:05.000000       1 main.go:14] failed to connect[0m
+			`),
+		},
+		{
+			name:  "kubectl options",
+			theme: DarkTheme(),
 			subcommandInfo: &kubectl.SubcommandInfo{
 				Subcommand: kubectl.Options,
 			},
@@ -456,8 +584,90 @@ func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 			`),
 		},
 		{
-			name:           "kubectl apply -o json",
-			darkBackground: true,
+			name:  "kubectl config get-contexts",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand:     kubectl.Config,
+				SubcommandPath: []string{"config", "get-contexts"},
+			},
+			input: testutil.NewHereDoc(`
+				CURRENT   NAME        CLUSTER     AUTHINFO    NAMESPACE
+				*         kind-kind   kind-kind   kind-kind   
+				          minikube    minikube    minikube    default`),
+			expected: testutil.NewHereDoc(`
+				[1;37mCURRENT   NAME        CLUSTER     AUTHINFO    NAMESPACE[0m
+				[36m*[0m         [32mkind-kind[0m   [35mkind-kind[0m   [37mkind-kind[0m   
+				          [32mminikube[0m    [35mminikube[0m    [37mminikube[0m    [33mdefault[0m
+			`),
+		},
+		{
+			name:  "kubectl auth can-i",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand:     kubectl.Auth,
+				SubcommandPath: []string{"auth", "can-i"},
+			},
+			input: testutil.NewHereDoc(`
+				no`),
+			expected: testutil.NewHereDoc(`
+				[31mno[0m
+			`),
+		},
+		{
+			name:  "kubectl rollout status",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand:     kubectl.Rollout,
+				SubcommandPath: []string{"rollout", "status"},
+			},
+			input: testutil.NewHereDoc(`
+				Waiting for deployment "nginx" rollout to finish: 1 of 3 updated replicas are available...
+				deployment "nginx" successfully rolled out`),
+			expected: testutil.NewHereDoc(`
+				[33mWaiting for deployment "nginx" rollout to finish: 1 of 3 updated replicas are available...[0m
+				[32mdeployment "nginx" successfully rolled out[0m
+			`),
+		},
+		{
+			name:  "kubectl rollout restart",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand:     kubectl.Rollout,
+				SubcommandPath: []string{"rollout", "restart"},
+			},
+			input: testutil.NewHereDoc(`
+				deployment.apps/nginx restarted`),
+			expected: testutil.NewHereDoc(`
+				deployment.apps/nginx [33mrestarted[0m
+			`),
+		},
+		{
+			name:  "kubectl scale",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand: kubectl.Scale,
+			},
+			input: testutil.NewHereDoc(`
+				deployment.apps/foo scaled`),
+			expected: testutil.NewHereDoc(`
+				deployment.apps/foo [33mscaled[0m
+			`),
+		},
+		{
+			name:  "kubectl label",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand: kubectl.Label,
+			},
+			input: testutil.NewHereDoc(`
+				pod/foo not labeled`),
+			expected: testutil.NewHereDoc(`
+				pod/foo [35mnot labeled[0m
+			`),
+		},
+		{
+			name:  "kubectl apply -o json",
+			theme: DarkTheme(),
 			subcommandInfo: &kubectl.SubcommandInfo{
 				Subcommand:   kubectl.Apply,
 				FormatOption: kubectl.Json,
@@ -491,8 +701,8 @@ func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 			`),
 		},
 		{
-			name:           "kubectl apply -o yaml",
-			darkBackground: true,
+			name:  "kubectl apply -o yaml",
+			theme: DarkTheme(),
 			subcommandInfo: &kubectl.SubcommandInfo{
 				Subcommand:   kubectl.Apply,
 				FormatOption: kubectl.Yaml,
@@ -556,6 +766,217 @@ func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 				  [37mupdatedReplicas[0m: [35m3[0m
 			`),
 		},
+		{
+			name:  "kubectl get -o name",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand:   kubectl.Get,
+				FormatOption: kubectl.Name,
+			},
+			input: testutil.NewHereDoc(`
+				pod/nginx-6799fc88d8-dnmv5
+				deployment.apps/nginx`),
+			expected: testutil.NewHereDoc(`
+				[33mpod[0m/[36mnginx-6799fc88d8-dnmv5[0m
+				[33mdeployment.apps[0m/[36mnginx[0m
+			`),
+		},
+		{
+			name:  "kubectl apply -o name",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand:   kubectl.Apply,
+				FormatOption: kubectl.Name,
+			},
+			input: testutil.NewHereDoc(`
+				deployment.apps/nginx`),
+			expected: testutil.NewHereDoc(`
+				[33mdeployment.apps[0m/[36mnginx[0m
+			`),
+		},
+		{
+			name:  "kubectl get -o custom-columns",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand:   kubectl.Get,
+				FormatOption: kubectl.CustomColumns,
+				Resources:    []string{"pods"},
+			},
+			input: testutil.NewHereDoc(`
+				NAME                     STATUS    NODE
+				nginx-6799fc88d8-dnmv5   Running   node-1
+				nginx-6799fc88d8-m8pbc   Pending   <none>`),
+			expected: testutil.NewHereDoc(`
+				[1;37mNAME                     STATUS    NODE[0m
+				[36mnginx-6799fc88d8-dnmv5[0m   [32mRunning[0m   [35mnode-1[0m
+				[36mnginx-6799fc88d8-m8pbc[0m   [33mPending[0m   [2m<none>[0m
+			`),
+		},
+		{
+			name:  "kubectl get -o custom-columns --no-headers",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand:   kubectl.Get,
+				FormatOption: kubectl.CustomColumnsFile,
+				NoHeader:     true,
+			},
+			input: testutil.NewHereDoc(`
+				nginx-6799fc88d8-dnmv5   node-1
+				nginx-6799fc88d8-m8pbc   node-2`),
+			expected: testutil.NewHereDoc(`
+				[36mnginx-6799fc88d8-dnmv5[0m   [32mnode-1[0m
+				[36mnginx-6799fc88d8-m8pbc[0m   [32mnode-2[0m
+			`),
+		},
+		{
+			name:  "kubectl get -o jsonpath prints text",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand:   kubectl.Get,
+				FormatOption: kubectl.JsonPath,
+			},
+			input: testutil.NewHereDoc(`
+				nginx-6799fc88d8-dnmv5 nginx-6799fc88d8-m8pbc`),
+			expected: testutil.NewHereDoc(`
+				[32mnginx-6799fc88d8-dnmv5 nginx-6799fc88d8-m8pbc[0m
+			`),
+		},
+		{
+			name:  "kubectl get -o jsonpath prints json",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand:   kubectl.Get,
+				FormatOption: kubectl.JsonPath,
+			},
+			input: testutil.NewHereDoc(`
+				{"name":"nginx","replicas":3}`),
+			expected: testutil.NewHereDoc(`
+				{"[37mname[0m":"[36mnginx[0m","[37mreplicas[0m":[35m3[0m}
+			`),
+		},
+		{
+			name:  "kubectl get -o go-template prints json array",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand:   kubectl.Get,
+				FormatOption: kubectl.GoTemplate,
+			},
+			input: testutil.NewHereDoc(`
+				[
+				  true
+				]`),
+			expected: testutil.NewHereDoc(`
+				[
+				  [32mtrue[0m
+				]
+			`),
+		},
+		{
+			name:  "kubectl cluster-info prints text",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand:     kubectl.ClusterInfo,
+				SubcommandPath: []string{"cluster-info"},
+			},
+			input: testutil.NewHereDoc(`
+				Kubernetes control plane is running at https://127.0.0.1:6443`),
+			expected: testutil.NewHereDoc(`
+				[32mKubernetes control plane is running at https://127.0.0.1:6443[0m
+			`),
+		},
+		{
+			name:  "kubectl port-forward prints text",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand:     kubectl.PortForward,
+				SubcommandPath: []string{"port-forward"},
+			},
+			input: testutil.NewHereDoc(`
+				Forwarding from 127.0.0.1:8080 -> 80
+				Handling connection for 8080`),
+			expected: testutil.NewHereDoc(`
+				[32mForwarding from 127.0.0.1:8080 -> 80[0m
+				[32mHandling connection for 8080[0m
+			`),
+		},
+		{
+			name:  "kubectl cluster-info dump prints json",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand:     kubectl.ClusterInfo,
+				SubcommandPath: []string{"cluster-info", "dump"},
+			},
+			input: testutil.NewHereDoc(`
+				{
+				    "kind": "NodeList"
+				}`),
+			expected: testutil.NewHereDoc(`
+				{
+				    "[37mkind[0m": "[36mNodeList[0m"
+				}
+			`),
+		},
+		{
+			name:  "kubectl config current-context prints text",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand:     kubectl.Config,
+				SubcommandPath: []string{"config", "current-context"},
+			},
+			input: testutil.NewHereDoc(`
+				minikube`),
+			expected: testutil.NewHereDoc(`
+				[32mminikube[0m
+			`),
+		},
+		{
+			name:  "kubectl plugin with auto printer guesses the format",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				SubcommandPath: []string{"neat"},
+				FormatOption:   kubectl.Name,
+				IsKrew:         true,
+			},
+			pluginPrinter: "auto",
+			input: testutil.NewHereDoc(`
+				apiVersion: v1
+				kind: Pod`),
+			expected: testutil.NewHereDoc(`
+				[33mapiVersion[0m: [36mv1[0m
+				[33mkind[0m: [36mPod[0m
+			`),
+		},
+		{
+			name:  "kubectl plugin with configured printer",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				SubcommandPath: []string{"tree"},
+				IsKrew:         true,
+			},
+			pluginPrinter: "table",
+			input: testutil.NewHereDoc(`
+				NAMESPACE  NAME              READY
+				default    Deployment/nginx  -`),
+			expected: testutil.NewHereDoc(`
+				[1;37mNAMESPACE  NAME              READY[0m
+				[36mdefault[0m    [32mDeployment/nginx[0m  [35m-[0m
+			`),
+		},
+		{
+			name:  "kubectl plugin with text printer",
+			theme: DarkTheme(),
+			subcommandInfo: &kubectl.SubcommandInfo{
+				Subcommand:     kubectl.Ctx,
+				SubcommandPath: []string{"ctx"},
+				IsKrew:         true,
+			},
+			pluginPrinter: "text",
+			input: testutil.NewHereDoc(`
+				kind: ok`),
+			expected: testutil.NewHereDoc(`
+				[32mkind: ok[0m
+			`),
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -565,7 +986,15 @@ func Test_KubectlOutputColoredPrinter_Print(t *testing.T) {
 			var w bytes.Buffer
 			printer := KubectlOutputColoredPrinter{
 				SubcommandInfo: tt.subcommandInfo,
-				DarkBackground: tt.darkBackground,
+				Theme:          tt.theme,
+			}
+			if tt.pluginPrinter != "" {
+				rule, ok := NewPluginRule(tt.subcommandInfo.Command(), tt.pluginPrinter)
+				if !ok {
+					t.Fatalf("unknown printer %s", tt.pluginPrinter)
+				}
+				printer.Registry = DefaultRegistry()
+				printer.Registry.Register(rule)
 			}
 			printer.Print(r, &w)
 			testutil.MustEqual(t, tt.expected, w.String())
@@ -0,0 +1,82 @@
+package printer
+
+import (
+	"io"
+	"strings"
+)
+
+// TemplatePrinter is a printer to print "-o jsonpath" and "-o go-template" output.
+// The output is whatever the template makes, so it is printed by JsonPrinter when it looks like json
+// (e.g. "-o jsonpath={.metadata}"), otherwise in the default color.
+type TemplatePrinter struct {
+	Theme *Theme
+}
+
+func (tp *TemplatePrinter) Print(r io.Reader, w io.Writer) {
+	head, rr := sniff(r, enoughToFindJSON)
+	defer rr.Close()
+
+	if looksLikeJSON(string(head)) {
+		(&JsonPrinter{Theme: tp.Theme}).Print(rr, w)
+		return
+	}
+
+	(&SingleColoredPrinter{Color: tp.Theme.Default}).Print(rr, w)
+}
+
+// enoughToFindJSON reports whether head is long enough for looksLikeJSON to decide,
+// that is, it has the first non-space character, and the next one if the first is an opening bracket.
+// A word after "[" needs some more characters to find if it is true, false or null.
+func enoughToFindJSON(head []byte) bool {
+	s := strings.TrimLeft(string(head), " \t\r\n")
+	if s == "" {
+		return false
+	}
+	if s[0] != '{' && s[0] != '[' {
+		return true
+	}
+
+	rest := strings.TrimLeft(s[1:], " \t\r\n")
+	if rest == "" {
+		return false
+	}
+
+	return s[0] == '{' || len(rest) >= len("false") || !isLetter(rest[0])
+}
+
+func isLetter(b byte) bool {
+	return ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z')
+}
+
+// looksLikeJSON reports whether head, the beginning of data, starts with a json object or array.
+func looksLikeJSON(head string) bool {
+	head = strings.TrimLeft(head, " \t\r\n")
+	if head == "" {
+		return false
+	}
+
+	var closing byte
+	switch head[0] {
+	case '{':
+		closing = '}'
+	case '[':
+		closing = ']'
+	default:
+		return false
+	}
+
+	rest := strings.TrimLeft(head[1:], " \t\r\n")
+	if rest == "" {
+		return false
+	}
+
+	c := rest[0]
+	if c == closing || c == '"' {
+		return true
+	}
+
+	// an array can contain any values, e.g. [1, 2], [true], [{"a": 1}]
+	// note that "[foo bar]" printed by go-template for a slice is not json
+	return head[0] == '[' && (c == '{' || c == '[' || c == '-' || (c >= '0' && c <= '9') ||
+		strings.HasPrefix(rest, "true") || strings.HasPrefix(rest, "false") || strings.HasPrefix(rest, "null"))
+}
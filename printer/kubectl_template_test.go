@@ -0,0 +1,173 @@
+package printer
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hidetatz/kubecolor/testutil"
+)
+
+func Test_TemplatePrinter_Print(t *testing.T) {
+	tests := []struct {
+		name     string
+		theme    *Theme
+		input    string
+		expected string
+	}{
+		{
+			name:  "text",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				nginx 3`),
+			expected: testutil.NewHereDoc(`
+				[32mnginx 3[0m
+			`),
+		},
+		{
+			name:  "go slice is not json",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				[nginx redis]`),
+			expected: testutil.NewHereDoc(`
+				[32m[nginx redis][0m
+			`),
+		},
+		{
+			name:  "json object",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				{
+				  "name": "nginx"
+				}`),
+			expected: testutil.NewHereDoc(`
+				{
+				  "[37mname[0m": "[36mnginx[0m"
+				}
+			`),
+		},
+		{
+			name:  "json array of numbers",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				[1, 2]`),
+			expected: testutil.NewHereDoc(`
+				[[35m1[0m, [35m2[0m]
+			`),
+		},
+		{
+			name:  "empty json object",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				{}`),
+			expected: testutil.NewHereDoc(`
+				{}
+			`),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := strings.NewReader(tt.input)
+			var w bytes.Buffer
+			printer := TemplatePrinter{Theme: tt.theme}
+			printer.Print(r, &w)
+			testutil.MustEqual(t, tt.expected, w.String())
+		})
+	}
+}
+
+// lockedBuffer is a buffer which can be written and read concurrently.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// printStreaming writes chunks to the printer one by one through a pipe which is kept open like "kubectl get -w",
+// then returns the output once it is as long as expected, or when it times out.
+func printStreaming(t *testing.T, printer Printer, chunks []string, expected string) string {
+	t.Helper()
+	r, w := io.Pipe()
+	t.Cleanup(func() { w.Close() })
+
+	var out lockedBuffer
+	go printer.Print(r, &out)
+	go func() {
+		for _, chunk := range chunks {
+			w.Write([]byte(chunk))
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for len(out.String()) < len(expected) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	return out.String()
+}
+
+func Test_TemplatePrinter_Print_Streaming(t *testing.T) {
+	tests := []struct {
+		name     string
+		chunks   []string
+		expected string
+	}{
+		{
+			name:   "short text",
+			chunks: []string{"nginx\n"},
+			expected: testutil.NewHereDoc(`
+				[32mnginx[0m
+			`),
+		},
+		{
+			name:   "short json",
+			chunks: []string{"{\"a\":1}\n"},
+			expected: testutil.NewHereDoc(`
+				{"[37ma[0m":[35m1[0m}
+			`),
+		},
+		{
+			name:   "json split into chunks",
+			chunks: []string{"{", "\n", "  \"a\": 1\n}\n"},
+			expected: testutil.NewHereDoc(`
+				{
+				  "[37ma[0m": [35m1[0m
+				}
+			`),
+		},
+		{
+			name:   "json array split into chunks",
+			chunks: []string{"[\n", "  t", "rue\n]\n"},
+			expected: testutil.NewHereDoc(`
+				[
+				  [32mtrue[0m
+				]
+			`),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := printStreaming(t, &TemplatePrinter{Theme: DarkTheme()}, tt.chunks, tt.expected)
+			testutil.MustEqual(t, tt.expected, got)
+		})
+	}
+}
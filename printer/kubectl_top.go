@@ -0,0 +1,166 @@
+package printer
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hidetatz/kubecolor/color"
+)
+
+// DefaultCPUThresholds and DefaultMemoryThresholds is thresholds of CPU% and MEMORY% in kubectl top node.
+var (
+	DefaultCPUThresholds    = Thresholds{Warning: 70, Error: 90}
+	DefaultMemoryThresholds = Thresholds{Warning: 70, Error: 90}
+)
+
+// relativeHeatThresholds is thresholds of a quantity in percent of the largest one in the same column.
+// It is used when usage percentages are not printed, e.g. kubectl top pod.
+var relativeHeatThresholds = Thresholds{Warning: 50, Error: 80}
+
+// quantity matches a resource quantity which kubectl top prints, e.g. "250m", "1.2Gi", "1024"
+var quantity = regexp.MustCompile(`^(\d+(?:\.\d+)?)(m|k|Ki|M|Mi|G|Gi|T|Ti|P|Pi|E|Ei)?$`)
+
+// quantitySuffixes is multipliers of quantity suffixes.
+var quantitySuffixes = map[string]float64{
+	"":   1,
+	"m":  1e-3,
+	"k":  1e3,
+	"M":  1e6,
+	"G":  1e9,
+	"T":  1e12,
+	"P":  1e15,
+	"E":  1e18,
+	"Ki": 1 << 10,
+	"Mi": 1 << 20,
+	"Gi": 1 << 30,
+	"Ti": 1 << 40,
+	"Pi": 1 << 50,
+	"Ei": 1 << 60,
+}
+
+// TopPrinter is a printer to print kubectl top.
+// Usage percentages are colorized from green to yellow to red by the thresholds.
+// When percentages are not printed (kubectl top pod), quantities are colorized by how large they are
+// compared to the largest one in the output, so the output is read at once to find the largest one.
+type TopPrinter struct {
+	WithHeader       bool
+	Theme            *Theme
+	CPUThresholds    Thresholds
+	MemoryThresholds Thresholds
+}
+
+// kubectl top node
+// NAME       CPU(cores)   CPU%   MEMORY(bytes)   MEMORY%
+// minikube   211m         10%    1125Mi          14%
+// kubectl top pod --containers
+// POD         NAME    CPU(cores)   MEMORY(bytes)
+// app-29twd   app     779m         221Mi
+// app-29twd   proxy   3m           18Mi
+func (tp *TopPrinter) Print(r io.Reader, w io.Writer) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	largest := tp.findLargestQuantities(lines)
+
+	table := NewTablePrinter(tp.WithHeader, tp.Theme, func(header, column string) (color.Color, bool) {
+		switch header {
+		case "CPU%", "CPU(%)":
+			return tp.colorByPercentage(column, tp.CPUThresholds)
+		case "MEMORY%", "MEMORY(%)":
+			return tp.colorByPercentage(column, tp.MemoryThresholds)
+		case "CPU(cores)", "MEMORY(bytes)":
+			max, ok := largest[header]
+			if !ok {
+				return 0, false
+			}
+			v, ok := parseQuantity(column)
+			if !ok {
+				return 0, false
+			}
+			return tp.heatColor(int(math.Round(v/max*100)), relativeHeatThresholds), true
+		}
+
+		return 0, false
+	})
+	table.Print(strings.NewReader(strings.Join(lines, "\n")), w)
+}
+
+// findLargestQuantities returns the largest quantity of each quantity column. A column where all are zero is not included.
+// It returns nothing when percentages are printed or the table doesn't have a header.
+func (tp *TopPrinter) findLargestQuantities(lines []string) map[string]float64 {
+	largest := map[string]float64{}
+	if !tp.WithHeader || len(lines) == 0 {
+		return largest
+	}
+
+	headers := parseTableHeader(lines[0])
+	for _, h := range headers {
+		if strings.Contains(h.name, "%") {
+			return largest
+		}
+	}
+
+	// a quantity can't be compared with others when there is only one row
+	if len(lines) < 3 {
+		return largest
+	}
+
+	for _, line := range lines[1:] {
+		columns := spaces.Split(line, -1)
+		if len(columns) != len(headers) {
+			continue
+		}
+
+		for i, h := range headers {
+			if h.name != "CPU(cores)" && h.name != "MEMORY(bytes)" {
+				continue
+			}
+			if v, ok := parseQuantity(columns[i]); ok && v > largest[h.name] {
+				largest[h.name] = v
+			}
+		}
+	}
+
+	return largest
+}
+
+// colorByPercentage colorizes a percentage, e.g. "10%", by the thresholds.
+func (tp *TopPrinter) colorByPercentage(column string, thresholds Thresholds) (color.Color, bool) {
+	n, err := strconv.Atoi(strings.TrimSuffix(column, "%"))
+	if err != nil || !strings.HasSuffix(column, "%") {
+		return 0, false
+	}
+
+	return tp.heatColor(n, thresholds), true
+}
+
+// heatColor returns green, yellow or red by the thresholds.
+func (tp *TopPrinter) heatColor(n int, thresholds Thresholds) color.Color {
+	if c, ok := thresholds.color(n, tp.Theme); ok {
+		return c
+	}
+
+	return tp.Theme.Success
+}
+
+// parseQuantity parses a resource quantity into a number in its base unit, e.g. "250m" is 0.25.
+func parseQuantity(s string) (float64, bool) {
+	m := quantity.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+
+	v, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return v * quantitySuffixes[m[2]], true
+}
@@ -0,0 +1,117 @@
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hidetatz/kubecolor/testutil"
+)
+
+func Test_TopPrinter_Print(t *testing.T) {
+	tests := []struct {
+		name     string
+		theme    *Theme
+		input    string
+		expected string
+	}{
+		{
+			name:  "top node, percentages are colored by thresholds",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				NAME     CPU(cores)   CPU%   MEMORY(bytes)   MEMORY%
+				node-1   211m         10%    1125Mi          14%
+				node-2   1800m        90%    6000Mi          75%
+				node-3   <unknown>    <unknown>   <unknown>  <unknown>`),
+			expected: testutil.NewHereDoc(`
+				[1;37mNAME     CPU(cores)   CPU%   MEMORY(bytes)   MEMORY%[0m
+				[36mnode-1[0m   [32m211m[0m         [32m10%[0m    [37m1125Mi[0m          [32m14%[0m
+				[36mnode-2[0m   [32m1800m[0m        [31m90%[0m    [37m6000Mi[0m          [33m75%[0m
+				[36mnode-3[0m   [2m<unknown>[0m    [2m<unknown>[0m   [2m<unknown>[0m  [2m<unknown>[0m
+			`),
+		},
+		{
+			name:  "top node of newer kubectl",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				NAME     CPU(cores)   CPU(%)   MEMORY(bytes)   MEMORY(%)
+				node-1   211m         69%      1125Mi          70%`),
+			expected: testutil.NewHereDoc(`
+				[1;37mNAME     CPU(cores)   CPU(%)   MEMORY(bytes)   MEMORY(%)[0m
+				[36mnode-1[0m   [32m211m[0m         [32m69%[0m      [37m1125Mi[0m          [33m70%[0m
+			`),
+		},
+		{
+			name:  "top pod --containers, quantities are colored relatively",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				POD         NAME    CPU(cores)   MEMORY(bytes)
+				app-29twd   app     779m         221Mi
+				app-29twd   proxy   3m           18Mi
+				app-2hhr6   app     1            120Mi
+				app-2hhr6   proxy   0m           0Mi`),
+			expected: testutil.NewHereDoc(`
+				[1;37mPOD         NAME    CPU(cores)   MEMORY(bytes)[0m
+				[36mapp-29twd[0m   [32mapp[0m     [33m779m[0m         [31m221Mi[0m
+				[36mapp-29twd[0m   [32mproxy[0m   [32m3m[0m           [32m18Mi[0m
+				[36mapp-2hhr6[0m   [32mapp[0m     [31m1[0m            [33m120Mi[0m
+				[36mapp-2hhr6[0m   [32mproxy[0m   [32m0m[0m           [32m0Mi[0m
+			`),
+		},
+		{
+			name:  "top pod with only one pod, it is not compared",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				NAME        CPU(cores)   MEMORY(bytes)
+				app-29twd   779m         221Mi`),
+			expected: testutil.NewHereDoc(`
+				[1;37mNAME        CPU(cores)   MEMORY(bytes)[0m
+				[36mapp-29twd[0m   [32m779m[0m         [35m221Mi[0m
+			`),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := strings.NewReader(tt.input)
+			var w bytes.Buffer
+			printer := TopPrinter{
+				WithHeader:       true,
+				Theme:            tt.theme,
+				CPUThresholds:    DefaultCPUThresholds,
+				MemoryThresholds: DefaultMemoryThresholds,
+			}
+			printer.Print(r, &w)
+			testutil.MustEqual(t, tt.expected, w.String())
+		})
+	}
+}
+
+func Test_parseQuantity(t *testing.T) {
+	tests := []struct {
+		s          string
+		expected   float64
+		expectedOK bool
+	}{
+		{"250m", 0.25, true},
+		{"2", 2, true},
+		{"1.5", 1.5, true},
+		{"1Ki", 1024, true},
+		{"221Mi", 221 * 1024 * 1024, true},
+		{"1.5Gi", 1.5 * 1024 * 1024 * 1024, true},
+		{"2G", 2e9, true},
+		{"<unknown>", 0, false},
+		{"10%", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.s, func(t *testing.T) {
+			t.Parallel()
+			v, ok := parseQuantity(tt.s)
+			testutil.MustEqual(t, tt.expectedOK, ok)
+			testutil.MustEqual(t, tt.expected, v)
+		})
+	}
+}
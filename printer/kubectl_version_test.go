@@ -10,15 +10,15 @@ import (
 
 func Test_VersionPrinter_Print(t *testing.T) {
 	tests := []struct {
-		name           string
-		darkBackground bool
-		recursive      bool
-		input          string
-		expected       string
+		name      string
+		theme     *Theme
+		recursive bool
+		input     string
+		expected  string
 	}{
 		{
-			name:           "go struct dump can be colorized",
-			darkBackground: true,
+			name:  "go struct dump can be colorized",
+			theme: DarkTheme(),
 			input: testutil.NewHereDoc(`
 				Client Version: version.Info{Major:"1", Minor:"19", GitVersion:"v1.19.3", GitCommit:"1e11e4a2108024935ecfcb2912226cedeafd99df", GitTreeState:"clean", BuildDate:"2020-10-14T18:49:28Z", GoVersion:"go1.15.2", Compiler:"gc", Platform:"darwin/amd64"}
 				Server Version: version.Info{Major:"1", Minor:"19", GitVersion:"v1.19.2", GitCommit:"f5743093fd1c663cb0cbc89748f730662345d44d", GitTreeState:"clean", BuildDate:"2020-09-16T13:32:58Z", GoVersion:"go1.15", Compiler:"gc", Platform:"linux/amd64"}`),
@@ -34,7 +34,7 @@ func Test_VersionPrinter_Print(t *testing.T) {
 			t.Parallel()
 			r := strings.NewReader(tt.input)
 			var w bytes.Buffer
-			printer := VersionPrinter{DarkBackground: tt.darkBackground}
+			printer := VersionPrinter{Theme: tt.theme}
 			printer.Print(r, &w)
 			testutil.MustEqual(t, tt.expected, w.String())
 		})
@@ -43,14 +43,14 @@ func Test_VersionPrinter_Print(t *testing.T) {
 
 func Test_VersionShortPrinter_Print(t *testing.T) {
 	tests := []struct {
-		name           string
-		darkBackground bool
-		input          string
-		expected       string
+		name     string
+		theme    *Theme
+		input    string
+		expected string
 	}{
 		{
-			name:           "--short can be colorized",
-			darkBackground: true,
+			name:  "--short can be colorized",
+			theme: DarkTheme(),
 			input: testutil.NewHereDoc(`
 				Client Version: v1.19.3
 				Server Version: v1.19.2`),
@@ -66,7 +66,7 @@ func Test_VersionShortPrinter_Print(t *testing.T) {
 			t.Parallel()
 			r := strings.NewReader(tt.input)
 			var w bytes.Buffer
-			printer := VersionShortPrinter{DarkBackground: tt.darkBackground}
+			printer := VersionShortPrinter{Theme: tt.theme}
 			printer.Print(r, &w)
 			testutil.MustEqual(t, tt.expected, w.String())
 		})
@@ -1,8 +1,11 @@
 package printer
 
 import (
+	"bytes"
 	"io"
 	"regexp"
+	"sort"
+	"time"
 )
 
 var singleOrMultipleSpaces = regexp.MustCompile("\\s{1,}")
@@ -13,3 +16,96 @@ var spaces = regexp.MustCompile("\\s{2,}")
 type Printer interface {
 	Print(r io.Reader, w io.Writer)
 }
+
+// printersByName is printers which can be chosen by name, e.g. for kubectl plugins in the config file.
+var printersByName = map[string]func(theme *Theme) Printer{
+	"auto": func(theme *Theme) Printer { return &AutoPrinter{Theme: theme} },
+	"json": func(theme *Theme) Printer { return &JsonPrinter{Theme: theme} },
+	"yaml": func(theme *Theme) Printer { return &YamlPrinter{Theme: theme} },
+	// a header is found by its letter case
+	"table": func(theme *Theme) Printer { return NewTablePrinter(false, theme, nil) },
+	"describe": func(theme *Theme) Printer {
+		return &DescribePrinter{Theme: theme, TablePrinter: NewTablePrinter(false, theme, nil)}
+	},
+	"diff": func(theme *Theme) Printer { return &DiffPrinter{Theme: theme} },
+	"text": func(theme *Theme) Printer { return &SingleColoredPrinter{Color: theme.Default} },
+}
+
+// PrinterByName returns a printer which has the given name. See PrinterNames for available names.
+func PrinterByName(name string, theme *Theme) (Printer, bool) {
+	fn, ok := printersByName[name]
+	if !ok {
+		return nil, false
+	}
+
+	return fn(theme), true
+}
+
+// PrinterNames returns names of printers which can be chosen by PrinterByName in alphabetical order.
+func PrinterNames() []string {
+	names := make([]string, 0, len(printersByName))
+	for name := range printersByName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// sniffTimeout is how long printers wait for more output to find its format after the first output arrives,
+// so that the output of long-running commands (e.g. streaming logs) is not held back.
+const sniffTimeout = 100 * time.Millisecond
+
+// sniff reads the beginning of r to find its format without waiting for the whole output.
+// It reads r until enough returns true for what has been read, r ends, or sniffTimeout passes after the first read.
+// It returns what has been read, and a reader to read r from the beginning, which must be closed after use.
+func sniff(r io.Reader, enough func(head []byte) bool) ([]byte, io.ReadCloser) {
+	// r is read in another goroutine not to block while waiting for more output
+	ch := make(chan []byte)
+	go func() {
+		defer close(ch)
+		for {
+			buf := make([]byte, 32*1024)
+			n, err := r.Read(buf)
+			if n > 0 {
+				ch <- buf[:n]
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	var head []byte
+	var timeout <-chan time.Time
+loop:
+	for !enough(head) {
+		select {
+		case b, ok := <-ch:
+			if !ok {
+				break loop
+			}
+			head = append(head, b...)
+			if timeout == nil {
+				timeout = time.After(sniffTimeout)
+			}
+		case <-timeout:
+			break loop
+		}
+	}
+
+	// the rest is given through a pipe.
+	// it is read until the end even if the reader is closed, not to leave the goroutine blocked.
+	pr, pw := io.Pipe()
+	go func() {
+		for b := range ch {
+			_, _ = pw.Write(b)
+		}
+		pw.Close()
+	}()
+
+	return head, struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), pr), pr}
+}
@@ -0,0 +1,187 @@
+package printer
+
+import (
+	"strings"
+
+	"github.com/hidetatz/kubecolor/kubectl"
+)
+
+// Priorities of rules in Registry. A rule with higher priority is chosen when several rules match the command.
+const (
+	// PriorityFallback is for rules which match any command, e.g. AutoPrinter.
+	PriorityFallback = -100
+	// PrioritySubcommand is for the default printer of a subcommand, e.g. TablePrinter for kubectl get.
+	PrioritySubcommand = 0
+	// PriorityFormat is for the printer of a subcommand with a specific format or flag, e.g. kubectl get -o json.
+	PriorityFormat = 10
+	// PriorityOutputFormat is for formats printed in the same way regardless of the subcommand, e.g. -o name.
+	PriorityOutputFormat = 20
+	// PriorityPlugin is for kubectl plugins, which are not colorized unless configured.
+	PriorityPlugin = 30
+	// PriorityHelp is for help messages, e.g. kubectl get -h.
+	PriorityHelp = 100
+	// PriorityUnsupported is for commands which are not colorized, e.g. kubectl exec.
+	PriorityUnsupported = 200
+	// PriorityUser is for rules configured by users, e.g. printers for plugins in the config file.
+	// It is prior to the built-in rules so that users can colorize commands which are not colorized by default.
+	PriorityUser = 300
+)
+
+// PrinterFactory returns a printer to print the output of the command in kp.SubcommandInfo.
+type PrinterFactory func(kp *KubectlOutputColoredPrinter) Printer
+
+// Rule is a rule to choose a printer for a command.
+// A rule matches a command when all of its conditions match. An empty condition matches any command.
+type Rule struct {
+	// Command is the subcommand path (or the plugin name) joined with spaces, e.g. "get", "config view".
+	// It matches sub-subcommands too, e.g. "rollout" matches "rollout status".
+	Command string
+	// Formats is output formats given by -o.
+	Formats []kubectl.FormatOption
+	// Resources is resource types in their canonical names, e.g. "pods". See kubectl.NormalizeResource.
+	// It matches when any of resources in the command is in it.
+	Resources []string
+	// Match is an additional condition, e.g. if --short is given.
+	Match func(info *kubectl.SubcommandInfo) bool
+
+	// Priority is the priority of the rule, e.g. PrioritySubcommand.
+	// When rules have the same priority, the one which has the longer Command is chosen,
+	// then the one registered later is chosen.
+	Priority int
+	// New returns the printer. When it is nil, the command is not colorized.
+	New PrinterFactory
+}
+
+// matches returns if the rule matches the command.
+func (rule *Rule) matches(info *kubectl.SubcommandInfo) bool {
+	if rule.Command != "" {
+		command := info.Command()
+		if command != rule.Command && !strings.HasPrefix(command, rule.Command+" ") {
+			return false
+		}
+	}
+
+	if len(rule.Formats) > 0 && !containsFormat(rule.Formats, info.FormatOption) {
+		return false
+	}
+
+	if len(rule.Resources) > 0 && !containsAny(rule.Resources, info.Resources) {
+		return false
+	}
+
+	return rule.Match == nil || rule.Match(info)
+}
+
+// prior returns if the rule is prior to other when both match the same command.
+// Rules are compared by priority, then the depth of Command.
+// When they are equal, it returns false, so the rule registered later is chosen.
+func (rule *Rule) prior(other *Rule) bool {
+	if rule.Priority != other.Priority {
+		return rule.Priority > other.Priority
+	}
+
+	return commandDepth(rule.Command) > commandDepth(other.Command)
+}
+
+func commandDepth(command string) int {
+	return len(strings.Fields(command))
+}
+
+func containsFormat(formats []kubectl.FormatOption, format kubectl.FormatOption) bool {
+	for _, f := range formats {
+		if f == format {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsAny(ss, targets []string) bool {
+	for _, s := range ss {
+		for _, t := range targets {
+			if s == t {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Registry holds rules to choose a printer for a command.
+// Use DefaultRegistry to add rules to the built-in ones, then set it in KubectlOutputColoredPrinter.Registry.
+type Registry struct {
+	rules []Rule
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds rules to the registry.
+func (r *Registry) Register(rules ...Rule) {
+	r.rules = append(r.rules, rules...)
+}
+
+// find returns the rule which is chosen for the command.
+// When skipUnsupported is true, rules without printer are ignored.
+func (r *Registry) find(info *kubectl.SubcommandInfo, skipUnsupported bool) *Rule {
+	var found *Rule
+	for i := range r.rules {
+		rule := &r.rules[i]
+		if skipUnsupported && rule.New == nil {
+			continue
+		}
+
+		if !rule.matches(info) {
+			continue
+		}
+
+		if found == nil || !found.prior(rule) {
+			found = rule
+		}
+	}
+
+	return found
+}
+
+// Supports returns if the command is colorized.
+// It is false when the chosen rule doesn't have printer or no rule matches the command.
+func (r *Registry) Supports(info *kubectl.SubcommandInfo) bool {
+	rule := r.find(info, false)
+	return rule != nil && rule.New != nil
+}
+
+// Lookup returns a printer for the command in kp.SubcommandInfo.
+// Rules without printer are ignored because such commands can still be colorized when enabled in the config file.
+// It returns nil when no rule matches the command.
+func (r *Registry) Lookup(kp *KubectlOutputColoredPrinter) Printer {
+	rule := r.find(kp.SubcommandInfo, true)
+	if rule == nil {
+		return nil
+	}
+
+	return rule.New(kp)
+}
+
+// NewPluginRule returns a rule to print the output of a kubectl plugin by the printer of the given name.
+// The plugin is its name in kubectl.SubcommandInfo.SubcommandPath joined with spaces, e.g. "neat", "cnpg status".
+// The printer is used even with -h, because flags of a plugin are its own.
+// It returns false when the printer is not found. See PrinterNames for available printers.
+func NewPluginRule(plugin, printerName string) (Rule, bool) {
+	if _, ok := printersByName[printerName]; !ok {
+		return Rule{}, false
+	}
+
+	return Rule{
+		Command:  plugin,
+		Match:    func(info *kubectl.SubcommandInfo) bool { return info.IsKrew },
+		Priority: PriorityUser,
+		New: func(kp *KubectlOutputColoredPrinter) Printer {
+			p, _ := PrinterByName(printerName, kp.Theme)
+			return p
+		},
+	}, true
+}
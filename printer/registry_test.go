@@ -0,0 +1,175 @@
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hidetatz/kubecolor/color"
+	"github.com/hidetatz/kubecolor/kubectl"
+	"github.com/hidetatz/kubecolor/testutil"
+)
+
+// coloredBy returns a factory of a printer which prints in the color, to find which rule is chosen.
+func coloredBy(c color.Color) PrinterFactory {
+	return func(kp *KubectlOutputColoredPrinter) Printer { return &SingleColoredPrinter{Color: c} }
+}
+
+func Test_Registry_Lookup(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(
+		Rule{Priority: PriorityFallback, New: coloredBy(color.White)},
+		Rule{Command: "get", Priority: PrioritySubcommand, New: coloredBy(color.Green)},
+		Rule{Command: "get", Formats: []kubectl.FormatOption{kubectl.Json}, Priority: PriorityFormat, New: coloredBy(color.Blue)},
+		Rule{Command: "get", Resources: []string{"pods"}, Priority: PrioritySubcommand, New: coloredBy(color.Cyan)},
+		Rule{Command: "config", Priority: PrioritySubcommand, New: coloredBy(color.Yellow)},
+		Rule{Command: "config view", Priority: PrioritySubcommand, New: coloredBy(color.Magenta)},
+		Rule{Command: "exec", Priority: PriorityUnsupported},
+		Rule{Match: func(info *kubectl.SubcommandInfo) bool { return info.Help }, Priority: PriorityHelp, New: coloredBy(color.Red)},
+	)
+
+	tests := []struct {
+		name              string
+		subcommandInfo    *kubectl.SubcommandInfo
+		expectedColor     color.Color
+		expectedSupported bool
+	}{
+		{
+			name:              "fallback",
+			subcommandInfo:    &kubectl.SubcommandInfo{Subcommand: kubectl.Describe, SubcommandPath: []string{"describe"}},
+			expectedColor:     color.White,
+			expectedSupported: true,
+		},
+		{
+			name:              "subcommand",
+			subcommandInfo:    &kubectl.SubcommandInfo{Subcommand: kubectl.Get, SubcommandPath: []string{"get"}, Resources: []string{"services"}},
+			expectedColor:     color.Green,
+			expectedSupported: true,
+		},
+		{
+			name:              "higher priority is chosen",
+			subcommandInfo:    &kubectl.SubcommandInfo{Subcommand: kubectl.Get, SubcommandPath: []string{"get"}, FormatOption: kubectl.Json, Resources: []string{"pods"}},
+			expectedColor:     color.Blue,
+			expectedSupported: true,
+		},
+		{
+			name:              "later registered is chosen in the same priority",
+			subcommandInfo:    &kubectl.SubcommandInfo{Subcommand: kubectl.Get, SubcommandPath: []string{"get"}, Resources: []string{"services", "pods"}},
+			expectedColor:     color.Cyan,
+			expectedSupported: true,
+		},
+		{
+			name:              "longer command is chosen in the same priority",
+			subcommandInfo:    &kubectl.SubcommandInfo{Subcommand: kubectl.Config, SubcommandPath: []string{"config", "view"}},
+			expectedColor:     color.Magenta,
+			expectedSupported: true,
+		},
+		{
+			name:              "command matches sub-subcommands",
+			subcommandInfo:    &kubectl.SubcommandInfo{Subcommand: kubectl.Config, SubcommandPath: []string{"config", "get-contexts"}},
+			expectedColor:     color.Yellow,
+			expectedSupported: true,
+		},
+		{
+			name:              "unsupported command uses the next rule",
+			subcommandInfo:    &kubectl.SubcommandInfo{Subcommand: kubectl.Exec, SubcommandPath: []string{"exec"}},
+			expectedColor:     color.White,
+			expectedSupported: false,
+		},
+		{
+			name:              "help",
+			subcommandInfo:    &kubectl.SubcommandInfo{Subcommand: kubectl.Get, SubcommandPath: []string{"get"}, Help: true},
+			expectedColor:     color.Red,
+			expectedSupported: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := registry.Lookup(&KubectlOutputColoredPrinter{SubcommandInfo: tt.subcommandInfo, Theme: DarkTheme()})
+			testutil.MustEqual(t, &SingleColoredPrinter{Color: tt.expectedColor}, p)
+			testutil.MustEqual(t, tt.expectedSupported, registry.Supports(tt.subcommandInfo))
+		})
+	}
+}
+
+func Test_Registry_LookupNotFound(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(Rule{Command: "get", New: coloredBy(color.Green)})
+
+	info := &kubectl.SubcommandInfo{Subcommand: kubectl.Describe, SubcommandPath: []string{"describe"}}
+	testutil.MustEqual(t, nil, registry.Lookup(&KubectlOutputColoredPrinter{SubcommandInfo: info, Theme: DarkTheme()}))
+	testutil.MustEqual(t, false, registry.Supports(info))
+}
+
+func Test_DefaultRegistry_Supports(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     *kubectl.SubcommandInfo
+		expected bool
+	}{
+		{"get", &kubectl.SubcommandInfo{Subcommand: kubectl.Get, SubcommandPath: []string{"get"}}, true},
+		{"cluster-info", &kubectl.SubcommandInfo{Subcommand: kubectl.ClusterInfo, SubcommandPath: []string{"cluster-info"}}, true},
+		{"exec", &kubectl.SubcommandInfo{Subcommand: kubectl.Exec, SubcommandPath: []string{"exec"}}, false},
+		{"exec -h", &kubectl.SubcommandInfo{Subcommand: kubectl.Exec, SubcommandPath: []string{"exec"}, Help: true}, false},
+		{"create secret", &kubectl.SubcommandInfo{Subcommand: kubectl.Create, SubcommandPath: []string{"create", "secret"}}, false},
+		{"plugin", &kubectl.SubcommandInfo{SubcommandPath: []string{"neat"}, IsKrew: true}, false},
+		{"ctx plugin", &kubectl.SubcommandInfo{Subcommand: kubectl.Ctx, SubcommandPath: []string{"ctx"}, IsKrew: true}, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			testutil.MustEqual(t, tt.expected, DefaultRegistry().Supports(tt.info))
+		})
+	}
+}
+
+func Test_KubectlOutputColoredPrinter_Print_Registry(t *testing.T) {
+	// rules added to the default registry override the built-in ones
+	registry := DefaultRegistry()
+	registry.Register(Rule{
+		Command:   "get",
+		Resources: []string{"secrets"},
+		Priority:  PriorityUser,
+		New:       coloredBy(color.Red),
+	})
+
+	info := &kubectl.SubcommandInfo{Subcommand: kubectl.Get, SubcommandPath: []string{"get"}, Resources: []string{"secrets"}}
+	var w bytes.Buffer
+	printer := KubectlOutputColoredPrinter{SubcommandInfo: info, Theme: DarkTheme(), Registry: registry}
+	printer.Print(strings.NewReader("NAME    TYPE\n"), &w)
+	testutil.MustEqual(t, "\x1b[31mNAME    TYPE\x1b[0m\n", w.String())
+}
+
+func Test_NewPluginRule_Supports(t *testing.T) {
+	registry := DefaultRegistry()
+	for _, plugin := range []string{"neat", "ctx"} {
+		rule, ok := NewPluginRule(plugin, "text")
+		if !ok {
+			t.Fatalf("unknown printer for %s", plugin)
+		}
+		registry.Register(rule)
+	}
+
+	tests := []struct {
+		name     string
+		info     *kubectl.SubcommandInfo
+		expected bool
+	}{
+		{"configured plugin", &kubectl.SubcommandInfo{SubcommandPath: []string{"neat"}, IsKrew: true}, true},
+		{"configured plugin is prior to unsupported commands", &kubectl.SubcommandInfo{Subcommand: kubectl.Ctx, SubcommandPath: []string{"ctx"}, IsKrew: true}, true},
+		{"help of configured plugin", &kubectl.SubcommandInfo{SubcommandPath: []string{"neat"}, IsKrew: true, Help: true}, true},
+		{"help of configured plugin is prior to unsupported commands", &kubectl.SubcommandInfo{Subcommand: kubectl.Ctx, SubcommandPath: []string{"ctx"}, IsKrew: true, Help: true}, true},
+		{"not configured plugin", &kubectl.SubcommandInfo{SubcommandPath: []string{"tree"}, IsKrew: true}, false},
+		{"help of not configured plugin", &kubectl.SubcommandInfo{SubcommandPath: []string{"tree"}, IsKrew: true, Help: true}, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			testutil.MustEqual(t, tt.expected, registry.Supports(tt.info))
+		})
+	}
+}
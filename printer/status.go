@@ -0,0 +1,112 @@
+package printer
+
+import (
+	"strings"
+
+	"github.com/hidetatz/kubecolor/color"
+)
+
+// statusKind is a semantic category of a resource status shown in kubectl output.
+type statusKind int
+
+const (
+	statusUnknown statusKind = iota
+	statusHealthy
+	statusTransitional
+	statusFailure
+)
+
+// statusKinds is a vocabulary of statuses across resource kinds.
+var statusKinds = map[string]statusKind{
+	// healthy
+	"Running":   statusHealthy,
+	"Ready":     statusHealthy,
+	"Bound":     statusHealthy,
+	"Active":    statusHealthy,
+	"Available": statusHealthy,
+	"Succeeded": statusHealthy,
+	"Completed": statusHealthy,
+	"Complete":  statusHealthy,
+	"Healthy":   statusHealthy,
+	"Approved":  statusHealthy,
+	"Issued":    statusHealthy,
+
+	// transitional
+	"Pending":            statusTransitional,
+	"ContainerCreating":  statusTransitional,
+	"PodInitializing":    statusTransitional,
+	"Terminating":        statusTransitional,
+	"Released":           statusTransitional,
+	"SchedulingDisabled": statusTransitional,
+	"Unknown":            statusTransitional,
+
+	// failure
+	"Error":                      statusFailure,
+	"Failed":                     statusFailure,
+	"CrashLoopBackOff":           statusFailure,
+	"ImagePullBackOff":           statusFailure,
+	"ErrImagePull":               statusFailure,
+	"ErrImageNeverPull":          statusFailure,
+	"InvalidImageName":           statusFailure,
+	"CreateContainerConfigError": statusFailure,
+	"CreateContainerError":       statusFailure,
+	"RunContainerError":          statusFailure,
+	"ContainerStatusUnknown":     statusFailure,
+	"OOMKilled":                  statusFailure,
+	"Evicted":                    statusFailure,
+	"NotReady":                   statusFailure,
+	"Lost":                       statusFailure,
+	"NodeLost":                   statusFailure,
+	"DeadlineExceeded":           statusFailure,
+	"BackOff":                    statusFailure,
+	"Unhealthy":                  statusFailure,
+	"Denied":                     statusFailure,
+}
+
+// getStatusKind returns the semantic category of the given status.
+// Besides the vocabulary, it understands some formats which kubectl shows:
+//
+//	Init:0/1, Init:CrashLoopBackOff: init containers are running or failed
+//	Signal:9, ExitCode:1:            containers are terminated abnormally
+//	Ready,SchedulingDisabled:        multiple statuses of a node, the worst one is used
+func getStatusKind(status string) statusKind {
+	if kind, ok := statusKinds[status]; ok {
+		return kind
+	}
+
+	if strings.Contains(status, ",") {
+		worst := statusUnknown
+		for _, s := range strings.Split(status, ",") {
+			if kind := getStatusKind(s); kind > worst {
+				worst = kind
+			}
+		}
+		return worst
+	}
+
+	switch {
+	case strings.HasPrefix(status, "Init:"):
+		if getStatusKind(strings.TrimPrefix(status, "Init:")) == statusFailure {
+			return statusFailure
+		}
+		return statusTransitional
+	case strings.HasPrefix(status, "Signal:"), strings.HasPrefix(status, "ExitCode:"):
+		return statusFailure
+	}
+
+	return statusUnknown
+}
+
+// getColorByStatus returns a color for the given status if the status is known.
+func getColorByStatus(status string, theme *Theme) (color.Color, bool) {
+	switch getStatusKind(status) {
+	case statusHealthy:
+		return theme.Success, true
+	case statusTransitional:
+		return theme.Warning, true
+	case statusFailure:
+		return theme.Error, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,51 @@
+package printer
+
+import (
+	"testing"
+
+	"github.com/hidetatz/kubecolor/color"
+)
+
+func Test_getColorByStatus(t *testing.T) {
+	theme := DarkTheme()
+	tests := []struct {
+		status     string
+		expected   color.Color
+		expectedOK bool
+	}{
+		{"Running", theme.Success, true},
+		{"Bound", theme.Success, true},
+		{"Active", theme.Success, true},
+		{"Completed", theme.Success, true},
+
+		{"Pending", theme.Warning, true},
+		{"ContainerCreating", theme.Warning, true},
+		{"Terminating", theme.Warning, true},
+		{"Init:0/1", theme.Warning, true},
+		{"Ready,SchedulingDisabled", theme.Warning, true},
+
+		{"CrashLoopBackOff", theme.Error, true},
+		{"ImagePullBackOff", theme.Error, true},
+		{"OOMKilled", theme.Error, true},
+		{"NotReady", theme.Error, true},
+		{"Lost", theme.Error, true},
+		{"Init:CrashLoopBackOff", theme.Error, true},
+		{"Init:ExitCode:1", theme.Error, true},
+		{"Signal:9", theme.Error, true},
+		{"NotReady,SchedulingDisabled", theme.Error, true},
+
+		{"nginx", 0, false},
+		{"1/1", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.status, func(t *testing.T) {
+			t.Parallel()
+			got, ok := getColorByStatus(tt.status, theme)
+			if got != tt.expected || ok != tt.expectedOK {
+				t.Errorf("fail: got: (%v, %v), expected: (%v, %v)", got, ok, tt.expected, tt.expectedOK)
+			}
+		})
+	}
+}
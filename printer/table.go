@@ -10,20 +10,32 @@ import (
 )
 
 type TablePrinter struct {
-	WithHeader     bool
-	DarkBackground bool
-	ColorDeciderFn func(index int, column string) (color.Color, bool)
+	WithHeader bool
+	Theme      *Theme
+	// ColorDeciderFn decides a color of a column.
+	// header is the name of the column in the table header (e.g. "STATUS"),
+	// it is empty when the table doesn't have a header.
+	ColorDeciderFn func(header, column string) (color.Color, bool)
+
+	isFirstLine    bool
+	headers        []tableHeader
+	headerColorMap map[string]color.Color
+	indexColorMap  map[int]color.Color
+	tempColors     []color.Color
+}
 
-	isFirstLine   bool
-	indexColorMap map[int]color.Color
-	tempColors    []color.Color
+// tableHeader is a column name in the table header and where it starts in the line.
+type tableHeader struct {
+	name  string
+	start int
 }
 
-func NewTablePrinter(withHeader, darkBackground bool, colorDeciderFn func(index int, column string) (color.Color, bool)) *TablePrinter {
+func NewTablePrinter(withHeader bool, theme *Theme, colorDeciderFn func(header, column string) (color.Color, bool)) *TablePrinter {
 	return &TablePrinter{
 		WithHeader:     withHeader,
-		DarkBackground: darkBackground,
+		Theme:          theme,
 		ColorDeciderFn: colorDeciderFn,
+		headerColorMap: map[string]color.Color{},
 		indexColorMap:  map[int]color.Color{},
 		tempColors:     []color.Color{},
 	}
@@ -35,12 +47,13 @@ func (tp *TablePrinter) Print(r io.Reader, w io.Writer) {
 	for scanner.Scan() {
 		line := scanner.Text()
 		if tp.isHeader(line) {
-			fmt.Fprintf(w, "%s\n", color.Apply(line, getHeaderColorByBackground(tp.DarkBackground)))
+			fmt.Fprintf(w, "%s\n", color.Apply(line, tp.Theme.Header))
+			tp.headers = parseTableHeader(line)
 			tp.isFirstLine = false
 			continue
 		}
 
-		tp.printLineAsTableFormat(w, line, getColorsByBackground(tp.DarkBackground))
+		tp.printLineAsTableFormat(w, line, tp.Theme.TableColumns)
 	}
 }
 
@@ -60,6 +73,52 @@ func (tp *TablePrinter) isHeader(line string) bool {
 	return (tp.WithHeader && tp.isFirstLine) || isEveryCharacterUpper
 }
 
+// parseTableHeader splits a table header line into column names.
+// e.g. "NAME   READY   NOMINATED NODE" is parsed as "NAME", "READY" and "NOMINATED NODE".
+func parseTableHeader(line string) []tableHeader {
+	names := spaces.Split(line, -1)
+	spacesIndices := spaces.FindAllStringIndex(line, -1)
+
+	headers := make([]tableHeader, 0, len(names))
+	for i, name := range names {
+		if name == "" {
+			continue
+		}
+
+		start := 0
+		if i != 0 {
+			start = spacesIndices[i-1][1]
+		}
+		headers = append(headers, tableHeader{name: name, start: start})
+	}
+
+	return headers
+}
+
+// findHeader returns the name of the column header which the i-th column in a line belongs to.
+// When the number of columns is the same as headers, the i-th header is used.
+// Otherwise some columns are empty (e.g. SHORTNAMES in kubectl api-resources), so the header is
+// found by where the column starts, because kubectl aligns a column with its header.
+func (tp *TablePrinter) findHeader(i, start, columnsCnt int) string {
+	if len(tp.headers) == 0 {
+		return ""
+	}
+
+	if columnsCnt == len(tp.headers) {
+		return tp.headers[i].name
+	}
+
+	name := tp.headers[0].name
+	for _, h := range tp.headers {
+		if h.start > start {
+			break
+		}
+		name = h.name
+	}
+
+	return name
+}
+
 // printTableFormat prints a line to w in kubectl "table" Format.
 // Table format is something like:
 // ---------------------------------------------------------
@@ -72,11 +131,12 @@ func (tp *TablePrinter) isHeader(line string) bool {
 // nginx-lpv5x              1/1     Running   0          31h
 // ---------------------------------------------------------
 // This function requires a line and tries to colorize it by each column.
-// If dark is true, use colors which are readable in dark-backgrounded environment, else,
-// it uses colors for light-backgrounded env.
+// Each column is colorized rotating the given colorsPreset.
 // This function doesn't respect if the line is "header", so
 // if you want to specify a special color for header, you must not pass the line
 // to this function.
+// When the table has a header, each column is colorized based on its header name, so the same column
+// always has the same color regardless of its position. Otherwise it is based on the position in the line.
 // deciderFn is a function to return context-specific color to be used to decorate a column.
 // If the function returned ok=true, then returned color will be used for the column.
 // If it returned ok=false, then default configurated color will be used.
@@ -96,14 +156,20 @@ func (tp *TablePrinter) printLineAsTableFormat(w io.Writer, line string, colorsP
 			index = spacesIndices[i-1][1] + 1
 		}
 
-		c := tp.decideColorForTable(index, colorsPreset)
+		header := tp.findHeader(i, index, len(columns))
+		c := tp.decideColorForTable(header, index, colorsPreset)
+		if isNoneValue(column) {
+			c = getColorByValueType(column, tp.Theme)
+		}
 		if tp.ColorDeciderFn != nil {
-			if cc, ok := tp.ColorDeciderFn(i, column); ok {
+			if cc, ok := tp.ColorDeciderFn(header, column); ok {
 				c = cc // prior injected deciderFn result
 			}
 		}
-		// Write colored column
-		fmt.Fprintf(w, "%s", color.Apply(column, c))
+		// Write colored column. An empty column made by leading or trailing spaces is not colorized.
+		if column != "" {
+			fmt.Fprintf(w, "%s", color.Apply(column, c))
+		}
 		// Write spaces based on actual output
 		// When writing the most left column, no extra spaces needed.
 		if i <= len(spacesIndices)-1 {
@@ -115,18 +181,28 @@ func (tp *TablePrinter) printLineAsTableFormat(w io.Writer, line string, colorsP
 	fmt.Fprintf(w, "\n")
 }
 
-func (tp *TablePrinter) decideColorForTable(index int, colors []color.Color) color.Color {
+// decideColorForTable returns a color for a column rotating colors.
+// The column is identified by its header name if it exists, otherwise by its position.
+func (tp *TablePrinter) decideColorForTable(header string, index int, colors []color.Color) color.Color {
 	if len(tp.tempColors) == 0 {
 		tp.tempColors = make([]color.Color, len(colors))
 		copy(tp.tempColors, colors)
 	}
 
-	if c, ok := tp.indexColorMap[index]; ok {
+	if header != "" {
+		if c, ok := tp.headerColorMap[header]; ok {
+			return c
+		}
+	} else if c, ok := tp.indexColorMap[index]; ok {
 		return c
 	}
 
 	c := tp.tempColors[0]
-	tp.indexColorMap[index] = c
+	if header != "" {
+		tp.headerColorMap[header] = c
+	} else {
+		tp.indexColorMap[index] = c
+	}
 	tp.tempColors = tp.tempColors[1:]
 
 	return c
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/hidetatz/kubecolor/color"
 	"github.com/hidetatz/kubecolor/testutil"
 )
@@ -13,9 +14,9 @@ import (
 func Test_TablePrinter_Print(t *testing.T) {
 	tests := []struct {
 		name           string
-		colorDeciderFn func(index int, column string) (color.Color, bool)
+		colorDeciderFn func(header, column string) (color.Color, bool)
 		withHeader     bool
-		darkBackground bool
+		theme          *Theme
 		input          string
 		expected       string
 	}{
@@ -23,14 +24,14 @@ func Test_TablePrinter_Print(t *testing.T) {
 			name:           "header is not colored - dark",
 			colorDeciderFn: nil,
 			withHeader:     true,
-			darkBackground: true,
+			theme:          DarkTheme(),
 			input: testutil.NewHereDoc(`
 				NAME          READY   STATUS    RESTARTS   AGE
 				nginx-dnmv5   1/1     Running   0          6d6h
 				nginx-m8pbc   1/1     Running   0          6d6h
 				nginx-qdf9b   1/1     Running   0          6d6h`),
 			expected: testutil.NewHereDoc(`
-				[37mNAME          READY   STATUS    RESTARTS   AGE[0m
+				[1;37mNAME          READY   STATUS    RESTARTS   AGE[0m
 				[36mnginx-dnmv5[0m   [32m1/1[0m     [35mRunning[0m   [37m0[0m          [33m6d6h[0m
 				[36mnginx-m8pbc[0m   [32m1/1[0m     [35mRunning[0m   [37m0[0m          [33m6d6h[0m
 				[36mnginx-qdf9b[0m   [32m1/1[0m     [35mRunning[0m   [37m0[0m          [33m6d6h[0m
@@ -40,7 +41,7 @@ func Test_TablePrinter_Print(t *testing.T) {
 			name:           "multiple headers",
 			colorDeciderFn: nil,
 			withHeader:     true,
-			darkBackground: true,
+			theme:          DarkTheme(),
 			input: testutil.NewHereDoc(`
 				NAME                         READY   STATUS    RESTARTS   AGE
 				pod/nginx-8spn9              1/1     Running   1          19d
@@ -52,21 +53,21 @@ func Test_TablePrinter_Print(t *testing.T) {
 				replicaset.apps/nginx-6799fc88d8   3         3         3       19d
 			`),
 			expected: testutil.NewHereDoc(`
-				[37mNAME                         READY   STATUS    RESTARTS   AGE[0m
+				[1;37mNAME                         READY   STATUS    RESTARTS   AGE[0m
 				[36mpod/nginx-8spn9[0m              [32m1/1[0m     [35mRunning[0m   [37m1[0m          [33m19d[0m
 				[36mpod/nginx-dplns[0m              [32m1/1[0m     [35mRunning[0m   [37m1[0m          [33m19d[0m
 				[36mpod/nginx-lpv5x[0m              [32m1/1[0m     [35mRunning[0m   [37m1[0m          [33m19d[0m
-				[37m[0m
-				[37mNAME                               DESIRED   CURRENT   READY   AGE[0m
-				[36mreplicaset.apps/nginx[0m              [36m3[0m         [32m3[0m         [35m3[0m       [37m19d[0m
-				[36mreplicaset.apps/nginx-6799fc88d8[0m   [36m3[0m         [32m3[0m         [35m3[0m       [37m19d[0m
+				[1;37m[0m
+				[1;37mNAME                               DESIRED   CURRENT   READY   AGE[0m
+				[36mreplicaset.apps/nginx[0m              [36m3[0m         [32m3[0m         [32m3[0m       [33m19d[0m
+				[36mreplicaset.apps/nginx-6799fc88d8[0m   [36m3[0m         [32m3[0m         [32m3[0m       [33m19d[0m
 			`),
 		},
 		{
 			name:           "withheader=false, 1st line is not colored in header color but colored as a content of table",
 			colorDeciderFn: nil,
 			withHeader:     false,
-			darkBackground: true,
+			theme:          DarkTheme(),
 			input: testutil.NewHereDoc(`
 				nginx-dnmv5   1/1     Running   0          6d6h
 				nginx-m8pbc   1/1     Running   0          6d6h
@@ -78,17 +79,17 @@ func Test_TablePrinter_Print(t *testing.T) {
 				`),
 		},
 		{
-			name:           "when darkBackground=false, color preset for light is used",
+			name:           "when the light theme is used, color preset for light is used",
 			colorDeciderFn: nil,
 			withHeader:     true,
-			darkBackground: false,
+			theme:          LightTheme(),
 			input: testutil.NewHereDoc(`
 				NAME          READY   STATUS    RESTARTS   AGE
 				nginx-dnmv5   1/1     Running   0          6d6h
 				nginx-m8pbc   1/1     Running   0          6d6h
 				nginx-qdf9b   1/1     Running   0          6d6h`),
 			expected: testutil.NewHereDoc(`
-				[30mNAME          READY   STATUS    RESTARTS   AGE[0m
+				[1;30mNAME          READY   STATUS    RESTARTS   AGE[0m
 				[36mnginx-dnmv5[0m   [32m1/1[0m     [35mRunning[0m   [30m0[0m          [33m6d6h[0m
 				[36mnginx-m8pbc[0m   [32m1/1[0m     [35mRunning[0m   [30m0[0m          [33m6d6h[0m
 				[36mnginx-qdf9b[0m   [32m1/1[0m     [35mRunning[0m   [30m0[0m          [33m6d6h[0m
@@ -96,7 +97,7 @@ func Test_TablePrinter_Print(t *testing.T) {
 		},
 		{
 			name: "colorDeciderFn works",
-			colorDeciderFn: func(_ int, column string) (color.Color, bool) {
+			colorDeciderFn: func(_, column string) (color.Color, bool) {
 				if column == "CrashLoopBackOff" {
 					return color.Red, true
 				}
@@ -115,8 +116,8 @@ func Test_TablePrinter_Print(t *testing.T) {
 
 				return 0, false
 			},
-			withHeader:     true,
-			darkBackground: true,
+			withHeader: true,
+			theme:      DarkTheme(),
 			// "CrashLoopBackOff" will be red, "0/1" will be yellow
 			input: testutil.NewHereDoc(`
 				NAME          READY   STATUS             RESTARTS   AGE
@@ -124,7 +125,7 @@ func Test_TablePrinter_Print(t *testing.T) {
 				nginx-m8pbc   1/1     Running            0          6d6h
 				nginx-qdf9b   0/1     Running            0          6d6h`),
 			expected: testutil.NewHereDoc(`
-				[37mNAME          READY   STATUS             RESTARTS   AGE[0m
+				[1;37mNAME          READY   STATUS             RESTARTS   AGE[0m
 				[36mnginx-dnmv5[0m   [32m1/1[0m     [31mCrashLoopBackOff[0m   [37m0[0m          [33m6d6h[0m
 				[36mnginx-m8pbc[0m   [32m1/1[0m     [35mRunning[0m            [37m0[0m          [33m6d6h[0m
 				[36mnginx-qdf9b[0m   [33m0/1[0m     [35mRunning[0m            [37m0[0m          [33m6d6h[0m
@@ -134,7 +135,7 @@ func Test_TablePrinter_Print(t *testing.T) {
 			name:           "a table whose some parts are missing can be handled",
 			colorDeciderFn: nil,
 			withHeader:     true,
-			darkBackground: true,
+			theme:          DarkTheme(),
 			input: testutil.NewHereDoc(`
 				NAME                              SHORTNAMES   APIGROUP                       NAMESPACED   KIND
 				bindings                                                                      true         Binding
@@ -154,7 +155,7 @@ func Test_TablePrinter_Print(t *testing.T) {
 				tokenreviews                                   authentication.k8s.io          false        TokenReview
 			`),
 			expected: testutil.NewHereDoc(`
-				[37mNAME                              SHORTNAMES   APIGROUP                       NAMESPACED   KIND[0m
+				[1;37mNAME                              SHORTNAMES   APIGROUP                       NAMESPACED   KIND[0m
 				[36mbindings[0m                                                                      [32mtrue[0m         [35mBinding[0m
 				[36mcomponentstatuses[0m                 [37mcs[0m                                          [32mfalse[0m        [35mComponentStatus[0m
 				[36mpods[0m                              [37mpo[0m                                          [32mtrue[0m         [35mPod[0m
@@ -172,6 +173,48 @@ func Test_TablePrinter_Print(t *testing.T) {
 				[36mtokenreviews[0m                                   [33mauthentication.k8s.io[0m          [32mfalse[0m        [35mTokenReview[0m
 			`),
 		},
+		{
+			name: "colorDeciderFn receives header name",
+			colorDeciderFn: func(header, column string) (color.Color, bool) {
+				if header == "STATUS" && column == "Running" {
+					return color.Red, true
+				}
+
+				return 0, false
+			},
+			withHeader: true,
+			theme:      DarkTheme(),
+			// "Running" in NAME column is not colored in red
+			input: testutil.NewHereDoc(`
+				NAME      READY   STATUS    RESTARTS   AGE
+				Running   1/1     Running   0          6d6h`),
+			expected: testutil.NewHereDoc(`
+				[1;37mNAME      READY   STATUS    RESTARTS   AGE[0m
+				[36mRunning[0m   [32m1/1[0m     [31mRunning[0m   [37m0[0m          [33m6d6h[0m
+			`),
+		},
+		{
+			name:           "the same column has the same color even if its position changes",
+			colorDeciderFn: nil,
+			withHeader:     true,
+			theme:          DarkTheme(),
+			input: testutil.NewHereDoc(`
+				NAME    STATUS    AGE
+				nginx   Running   6d6h
+
+				NAME                   STATUS   AGE
+				nginx-long-long-name   Bound    6d6h
+				nginx                           6d6h
+			`),
+			expected: testutil.NewHereDoc(`
+				[1;37mNAME    STATUS    AGE[0m
+				[36mnginx[0m   [32mRunning[0m   [35m6d6h[0m
+				[1;37m[0m
+				[1;37mNAME                   STATUS   AGE[0m
+				[36mnginx-long-long-name[0m   [32mBound[0m    [35m6d6h[0m
+				[36mnginx[0m                           [35m6d6h[0m
+			`),
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -179,9 +222,22 @@ func Test_TablePrinter_Print(t *testing.T) {
 			t.Parallel()
 			r := strings.NewReader(tt.input)
 			var w bytes.Buffer
-			printer := NewTablePrinter(tt.withHeader, tt.darkBackground, tt.colorDeciderFn)
+			printer := NewTablePrinter(tt.withHeader, tt.theme, tt.colorDeciderFn)
 			printer.Print(r, &w)
 			testutil.MustEqual(t, tt.expected, w.String())
 		})
 	}
 }
+
+func Test_parseTableHeader(t *testing.T) {
+	got := parseTableHeader("NAME          READY   NOMINATED NODE   AGE")
+	expected := []tableHeader{
+		{name: "NAME", start: 0},
+		{name: "READY", start: 14},
+		{name: "NOMINATED NODE", start: 22},
+		{name: "AGE", start: 39},
+	}
+	if diff := cmp.Diff(expected, got, cmp.AllowUnexported(tableHeader{})); diff != "" {
+		t.Errorf("diff (-want +got):\n%s", diff)
+	}
+}
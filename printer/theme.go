@@ -0,0 +1,352 @@
+package printer
+
+import (
+	"sort"
+
+	"github.com/hidetatz/kubecolor/color"
+)
+
+// Theme is a set of colors used by printers.
+// Each field is a semantic role, so printers don't have to know what color is actually used.
+type Theme struct {
+	// Key is colors for keys in structured data (e.g. Json, Yaml, kubectl-describe format).
+	// A color is chosen by the depth of the key, rotating this slice.
+	Key []color.Color
+
+	// colors for values in structured data
+	String color.Color
+	Number color.Color
+	Bool   color.Color
+	Null   color.Color
+	None   color.Color // for "<none>", "<unknown>"
+
+	// Header is a color for table header
+	Header color.Color
+	// TableColumns is colors for table columns. Each column gets a color rotating this slice.
+	TableColumns []color.Color
+
+	// colors for actions in mutating commands, e.g. kubectl apply, scale, label
+	Created    color.Color
+	Configured color.Color
+	Deleted    color.Color // e.g. "pruned", "evicted"
+	Unchanged  color.Color // actions which changed nothing, e.g. "unchanged", "not labeled"
+	DryRun     color.Color
+
+	// colors for statuses, e.g. Running (success), Pending (warning), CrashLoopBackOff (error)
+	Success color.Color
+	Warning color.Color
+	Error   color.Color
+
+	// colors for logs. Error and warning logs use Error and Warning.
+	Info      color.Color
+	Debug     color.Color
+	Timestamp color.Color
+	// LogPrefixes is colors for "[pod/name/container]" prefixes given by kubectl logs --prefix.
+	// A color is chosen by the hash of the prefix, so the same container always gets the same color.
+	LogPrefixes []color.Color
+
+	// colors for unified diff, e.g. kubectl diff
+	DiffHeader  color.Color // "diff", "---" and "+++" lines
+	DiffHunk    color.Color // "@@ -1,2 +1,2 @@"
+	DiffAdded   color.Color
+	DiffRemoved color.Color
+	// colors for words which are actually changed in added or removed lines
+	DiffAddedWord   color.Color
+	DiffRemovedWord color.Color
+
+	// colors for yaml tokens which are not keys nor values
+	Comment color.Color // "# comment"
+	Anchor  color.Color // "&anchor", "*alias"
+	Tag     color.Color // "!!str", "!custom"
+
+	// colors for durations by recency, e.g. AGE, LAST SEEN. Old ones use the column color.
+	AgeFresh  color.Color // less than 10 minutes
+	AgeRecent color.Color // less than a day
+
+	// Default is a color for output which kubecolor doesn't know how to colorize
+	Default color.Color
+	// Help is a color for help messages
+	Help color.Color
+}
+
+// DarkTheme returns a theme for dark-backgrounded terminal. This is the default.
+func DarkTheme() *Theme {
+	return &Theme{
+		Key:             []color.Color{color.Yellow, color.White},
+		String:          color.Cyan,
+		Number:          color.Magenta,
+		Bool:            color.Green,
+		Null:            color.Yellow,
+		None:            color.Faint,
+		Header:          color.White.With(color.Bold),
+		TableColumns:    []color.Color{color.Cyan, color.Green, color.Magenta, color.White, color.Yellow},
+		Created:         color.Green,
+		Configured:      color.Yellow,
+		Deleted:         color.Red,
+		Unchanged:       color.Magenta,
+		DryRun:          color.Cyan,
+		Success:         color.Green,
+		Error:           color.Red,
+		Warning:         color.Yellow,
+		Info:            color.Green,
+		Debug:           color.Faint,
+		Timestamp:       color.Blue,
+		LogPrefixes:     []color.Color{color.Cyan, color.Green, color.Magenta, color.Yellow, color.Blue},
+		DiffHeader:      color.White.With(color.Bold),
+		DiffHunk:        color.Cyan,
+		DiffAdded:       color.Green,
+		DiffRemoved:     color.Red,
+		DiffAddedWord:   color.Green.With(color.Reverse),
+		DiffRemovedWord: color.Red.With(color.Reverse),
+		Comment:         color.Faint,
+		Anchor:          color.Blue,
+		Tag:             color.Magenta.With(color.Italic),
+		AgeFresh:        color.Green.With(color.Bold),
+		AgeRecent:       color.Green,
+		Default:         color.Green,
+		Help:            color.Yellow,
+	}
+}
+
+// LightTheme returns a theme for light-backgrounded terminal.
+func LightTheme() *Theme {
+	return &Theme{
+		Key:             []color.Color{color.Yellow, color.Black},
+		String:          color.Blue,
+		Number:          color.Magenta,
+		Bool:            color.Green,
+		Null:            color.Yellow,
+		None:            color.Faint,
+		Header:          color.Black.With(color.Bold),
+		TableColumns:    []color.Color{color.Cyan, color.Green, color.Magenta, color.Black, color.Yellow, color.Blue},
+		Created:         color.Green,
+		Configured:      color.Yellow,
+		Deleted:         color.Red,
+		Unchanged:       color.Magenta,
+		DryRun:          color.Blue,
+		Success:         color.Green,
+		Error:           color.Red,
+		Warning:         color.Yellow,
+		Info:            color.Green,
+		Debug:           color.Faint,
+		Timestamp:       color.Blue,
+		LogPrefixes:     []color.Color{color.Cyan, color.Green, color.Magenta, color.Yellow, color.Blue},
+		DiffHeader:      color.Black.With(color.Bold),
+		DiffHunk:        color.Cyan,
+		DiffAdded:       color.Green,
+		DiffRemoved:     color.Red,
+		DiffAddedWord:   color.Green.With(color.Reverse),
+		DiffRemovedWord: color.Red.With(color.Reverse),
+		Comment:         color.Faint,
+		Anchor:          color.Blue,
+		Tag:             color.Magenta.With(color.Italic),
+		AgeFresh:        color.Green.With(color.Bold),
+		AgeRecent:       color.Green,
+		Default:         color.Green,
+		Help:            color.Yellow,
+	}
+}
+
+// SolarizedTheme returns a theme using Solarized palette (https://ethanschoonover.com/solarized/).
+// It works on both of Solarized dark and light terminals.
+func SolarizedTheme() *Theme {
+	var (
+		yellow  = color.RGB(0xb5, 0x89, 0x00)
+		orange  = color.RGB(0xcb, 0x4b, 0x16)
+		red     = color.RGB(0xdc, 0x32, 0x2f)
+		magenta = color.RGB(0xd3, 0x36, 0x82)
+		violet  = color.RGB(0x6c, 0x71, 0xc4)
+		blue    = color.RGB(0x26, 0x8b, 0xd2)
+		cyan    = color.RGB(0x2a, 0xa1, 0x98)
+		green   = color.RGB(0x85, 0x99, 0x00)
+		base0   = color.RGB(0x83, 0x94, 0x96)
+	)
+
+	return &Theme{
+		Key:             []color.Color{yellow, blue},
+		String:          cyan,
+		Number:          magenta,
+		Bool:            green,
+		Null:            orange,
+		None:            base0.With(color.Faint),
+		Header:          base0.With(color.Bold),
+		TableColumns:    []color.Color{cyan, green, violet, blue, yellow},
+		Created:         green,
+		Configured:      yellow,
+		Deleted:         red,
+		Unchanged:       violet,
+		DryRun:          cyan,
+		Success:         green,
+		Error:           red,
+		Warning:         orange,
+		Info:            green,
+		Debug:           base0.With(color.Faint),
+		Timestamp:       blue,
+		LogPrefixes:     []color.Color{cyan, green, violet, blue, yellow, magenta},
+		DiffHeader:      base0.With(color.Bold),
+		DiffHunk:        violet,
+		DiffAdded:       green,
+		DiffRemoved:     red,
+		DiffAddedWord:   green.With(color.Reverse),
+		DiffRemovedWord: red.With(color.Reverse),
+		Comment:         base0.With(color.Faint),
+		Anchor:          violet,
+		Tag:             magenta.With(color.Italic),
+		AgeFresh:        green.With(color.Bold),
+		AgeRecent:       green,
+		Default:         green,
+		Help:            yellow,
+	}
+}
+
+// HighContrastTheme returns a theme using bright and bold colors for better readability.
+func HighContrastTheme() *Theme {
+	var (
+		white   = color.Color256(15)
+		yellow  = color.Color256(11)
+		cyan    = color.Color256(14)
+		green   = color.Color256(10)
+		magenta = color.Color256(13)
+		red     = color.Color256(9)
+	)
+
+	return &Theme{
+		Key:             []color.Color{yellow.With(color.Bold), white.With(color.Bold)},
+		String:          cyan,
+		Number:          magenta,
+		Bool:            green,
+		Null:            yellow,
+		None:            white.With(color.Italic),
+		Header:          color.Black.On(white).With(color.Bold),
+		TableColumns:    []color.Color{cyan, green, magenta, white, yellow},
+		Created:         green.With(color.Bold),
+		Configured:      yellow.With(color.Bold),
+		Deleted:         red,
+		Unchanged:       magenta.With(color.Bold),
+		DryRun:          cyan.With(color.Bold),
+		Success:         green,
+		Error:           red.With(color.Bold),
+		Warning:         yellow.With(color.Bold),
+		Info:            green,
+		Debug:           white.With(color.Italic),
+		Timestamp:       color.Color256(12),
+		LogPrefixes:     []color.Color{cyan, green, magenta, yellow, color.Color256(12)},
+		DiffHeader:      white.With(color.Bold),
+		DiffHunk:        cyan,
+		DiffAdded:       green,
+		DiffRemoved:     red,
+		DiffAddedWord:   color.Black.On(green).With(color.Bold),
+		DiffRemovedWord: color.Black.On(red).With(color.Bold),
+		Comment:         white.With(color.Italic),
+		Anchor:          color.Color256(12),
+		Tag:             magenta.With(color.Italic),
+		AgeFresh:        green.With(color.Bold),
+		AgeRecent:       green,
+		Default:         green,
+		Help:            yellow,
+	}
+}
+
+// ColorblindSafeTheme returns a theme using Okabe-Ito palette,
+// which is distinguishable with common types of color vision deficiency.
+// It avoids using red and green as a pair.
+func ColorblindSafeTheme() *Theme {
+	var (
+		orange        = color.RGB(0xe6, 0x9f, 0x00)
+		skyBlue       = color.RGB(0x56, 0xb4, 0xe9)
+		bluishGreen   = color.RGB(0x00, 0x9e, 0x73)
+		yellow        = color.RGB(0xf0, 0xe4, 0x42)
+		blue          = color.RGB(0x00, 0x72, 0xb2)
+		vermillion    = color.RGB(0xd5, 0x5e, 0x00)
+		reddishPurple = color.RGB(0xcc, 0x79, 0xa7)
+	)
+
+	return &Theme{
+		Key:             []color.Color{orange, skyBlue},
+		String:          skyBlue,
+		Number:          reddishPurple,
+		Bool:            bluishGreen,
+		Null:            yellow,
+		None:            color.Faint,
+		Header:          color.Bold,
+		TableColumns:    []color.Color{skyBlue, orange, bluishGreen, yellow, reddishPurple},
+		Created:         blue,
+		Configured:      orange,
+		Deleted:         vermillion,
+		Unchanged:       reddishPurple,
+		DryRun:          skyBlue,
+		Success:         bluishGreen,
+		Error:           vermillion.With(color.Bold),
+		Warning:         orange,
+		Info:            bluishGreen,
+		Debug:           color.Faint,
+		Timestamp:       blue,
+		LogPrefixes:     []color.Color{skyBlue, orange, bluishGreen, yellow, reddishPurple, blue},
+		DiffHeader:      color.Bold,
+		DiffHunk:        reddishPurple,
+		DiffAdded:       blue,
+		DiffRemoved:     vermillion,
+		DiffAddedWord:   blue.With(color.Reverse),
+		DiffRemovedWord: vermillion.With(color.Reverse),
+		Comment:         color.Faint,
+		Anchor:          blue,
+		Tag:             reddishPurple.With(color.Italic),
+		AgeFresh:        bluishGreen.With(color.Bold),
+		AgeRecent:       bluishGreen,
+		Default:         skyBlue,
+		Help:            yellow,
+	}
+}
+
+var themes = map[string]func() *Theme{
+	"dark":            DarkTheme,
+	"light":           LightTheme,
+	"solarized":       SolarizedTheme,
+	"high-contrast":   HighContrastTheme,
+	"colorblind-safe": ColorblindSafeTheme,
+}
+
+// themeBackgrounds is whether built-in themes are made for dark background (true) or light background (false).
+// Themes which work on both (e.g. solarized, colorblind-safe) are not here.
+var themeBackgrounds = map[string]bool{
+	"dark":          true,
+	"light":         false,
+	"high-contrast": true,
+}
+
+// ThemeBackground returns if the built-in theme of the given name is made for dark background.
+// ok is false when the theme works on both dark and light background, or the theme is not found.
+func ThemeBackground(name string) (dark bool, ok bool) {
+	dark, ok = themeBackgrounds[name]
+	return dark, ok
+}
+
+// ThemeByName returns a built-in theme which has the given name.
+func ThemeByName(name string) (*Theme, bool) {
+	fn, ok := themes[name]
+	if !ok {
+		return nil, false
+	}
+
+	return fn(), true
+}
+
+// ThemeNames returns names of built-in themes in alphabetical order.
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// ThemeByBackground returns the default theme for the terminal background.
+func ThemeByBackground(dark bool) *Theme {
+	if dark {
+		return DarkTheme()
+	}
+
+	return LightTheme()
+}
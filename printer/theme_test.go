@@ -0,0 +1,37 @@
+package printer
+
+import (
+	"testing"
+
+	"github.com/hidetatz/kubecolor/testutil"
+)
+
+func Test_ThemeByName(t *testing.T) {
+	for _, name := range ThemeNames() {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			theme, ok := ThemeByName(name)
+			if !ok {
+				t.Fatalf("theme %s must be found", name)
+			}
+
+			if len(theme.Key) == 0 || len(theme.TableColumns) == 0 || len(theme.LogPrefixes) == 0 {
+				t.Errorf("theme %s must have key colors, table colors and log prefix colors", name)
+			}
+		})
+	}
+
+	if _, ok := ThemeByName("unknown"); ok {
+		t.Errorf("unknown theme must not be found")
+	}
+}
+
+func Test_ThemeNames(t *testing.T) {
+	testutil.MustEqual(t, []string{"colorblind-safe", "dark", "high-contrast", "light", "solarized"}, ThemeNames())
+}
+
+func Test_ThemeByBackground(t *testing.T) {
+	testutil.MustEqual(t, DarkTheme(), ThemeByBackground(true))
+	testutil.MustEqual(t, LightTheme(), ThemeByBackground(false))
+}
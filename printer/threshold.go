@@ -0,0 +1,37 @@
+package printer
+
+import (
+	"github.com/hidetatz/kubecolor/color"
+)
+
+// Thresholds is thresholds of a numeric value to colorize it.
+// A value greater than or equal to Warning is colorized in the warning color,
+// and greater than or equal to Error is in the error color.
+type Thresholds struct {
+	Warning int
+	Error   int
+}
+
+// DefaultRestartsThresholds is thresholds of RESTARTS in kubectl get pods.
+var DefaultRestartsThresholds = Thresholds{Warning: 1, Error: 10}
+
+// color returns a color for n if it exceeds the thresholds.
+func (t Thresholds) color(n int, theme *Theme) (color.Color, bool) {
+	switch {
+	case n >= t.Error:
+		return theme.Error, true
+	case n >= t.Warning:
+		return theme.Warning, true
+	}
+
+	return 0, false
+}
+
+// thresholdsOrDefault returns t, or defaults when t is zero, which means it's not configured.
+func thresholdsOrDefault(t, defaults Thresholds) Thresholds {
+	if t == (Thresholds{}) {
+		return defaults
+	}
+
+	return t
+}
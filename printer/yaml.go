@@ -9,121 +9,386 @@ import (
 	"github.com/hidetatz/kubecolor/color"
 )
 
+// YamlPrinter is a printer to print yaml.
+// It scans yaml line by line remembering what the previous lines started,
+// e.g. block scalars ("key: |"), multi-line plain or quoted scalars and flow collections ("{a: 1}"),
+// so that each token is colorized by its role. The output is the same as the input when colors are removed.
 type YamlPrinter struct {
-	DarkBackground bool
+	Theme *Theme
 
-	inString bool
+	// inBlockScalar and inPlainScalar are true when the previous line started a block scalar or a plain scalar
+	// which can continue to the following lines. They continue while lines are indented more than scalarParent.
+	inBlockScalar bool
+	inPlainScalar bool
+	scalarParent  int
+
+	// quote is the quote character of a quoted scalar which is not closed yet
+	quote byte
+
+	// flowDepth is the depth of flow collections which are not closed yet.
+	// flowKeyDepth is the depth of keys in the flow collection where it starts.
+	flowDepth    int
+	flowKeyDepth int
 }
 
 func (yp *YamlPrinter) Print(r io.Reader, w io.Writer) {
-	scanner := bufio.NewScanner(r)
-	for scanner.Scan() {
-		line := scanner.Text()
-		yp.printLineAsYamlFormat(line, w, yp.DarkBackground)
+	// bufio.Scanner is not used because it can't read a line longer than its buffer,
+	// e.g. kubectl.kubernetes.io/last-applied-configuration annotation.
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			fmt.Fprintf(w, "%s\n", yp.colorizeLine(strings.TrimSuffix(line, "\n")))
+		}
+		if err != nil {
+			return
+		}
 	}
 }
 
-func (yp *YamlPrinter) printLineAsYamlFormat(line string, w io.Writer, dark bool) {
-	indentCnt := findIndent(line) // can be 0
-	indent := toSpaces(indentCnt) // so, can be empty
-	trimmedLine := strings.TrimLeft(line, " ")
+func (yp *YamlPrinter) colorizeLine(line string) string {
+	indentCnt := findIndent(line)
+	indent, body := line[:indentCnt], line[indentCnt:]
 
-	if yp.inString {
-		// if inString is true, the line must be a part of a string which is broken into several lines
-		fmt.Fprintf(w, "%s%s\n", indent, yp.toColorizedStringValue(trimmedLine, dark))
-		yp.inString = !yp.isStringClosed(trimmedLine)
-		return
+	// a document marker ends everything in the previous document.
+	// It can be followed by a value, e.g. "--- |", "--- # comment"
+	if indentCnt == 0 && isDocumentMarker(body) {
+		yp.inBlockScalar, yp.inPlainScalar, yp.quote, yp.flowDepth = false, false, 0, 0
+		return color.Apply(body[:3], yp.Theme.Header) + yp.colorizeValue(body[3:], indentCnt, 0)
 	}
 
-	splitted := strings.SplitN(trimmedLine, ": ", 2) // assuming key does not contain ": " while value might do
+	switch {
+	case yp.quote != 0:
+		return indent + yp.colorizeQuotedRest(body)
+
+	case yp.inBlockScalar || yp.inPlainScalar:
+		// empty lines can be in a scalar
+		if strings.TrimSpace(body) == "" {
+			return line
+		}
+		if indentCnt > yp.scalarParent {
+			return indent + color.Apply(body, yp.Theme.String)
+		}
+		yp.inBlockScalar, yp.inPlainScalar = false, false
 
-	if len(splitted) == 2 {
-		// key: value
-		key, val := splitted[0], splitted[1]
-		fmt.Fprintf(w, "%s%s: %s\n", indent, yp.toColorizedYamlKey(key, indentCnt, 2, dark), yp.toColorizedYamlValue(val, dark))
-		yp.inString = yp.isStringOpenedButNotClosed(val)
-		return
+	case yp.flowDepth > 0:
+		return indent + yp.colorizeFlow(body)
 	}
 
-	// when coming here, the line is just a "key:" or an element of an array
-	if strings.HasSuffix(splitted[0], ":") {
-		// key:
-		fmt.Fprintf(w, "%s%s\n", indent, yp.toColorizedYamlKey(splitted[0], indentCnt, 2, dark))
-		return
+	switch {
+	case body == "":
+		return line
+	case strings.HasPrefix(body, "#"):
+		return indent + color.Apply(body, yp.Theme.Comment)
 	}
 
-	fmt.Fprintf(w, "%s%s\n", indent, yp.toColorizedYamlValue(splitted[0], dark))
+	return indent + yp.colorizeNode(body, indentCnt)
 }
 
-func (yp *YamlPrinter) toColorizedYamlKey(key string, indentCnt, basicWidth int, dark bool) string {
-	hasColon := strings.HasSuffix(key, ":")
-	hasLeadingDash := strings.HasPrefix(key, "- ")
-	key = strings.TrimSuffix(key, ":")
-	key = strings.TrimPrefix(key, "- ")
+// colorizeNode colorizes a sequence entry, a mapping entry or a scalar which starts at column col.
+func (yp *YamlPrinter) colorizeNode(s string, col int) string {
+	// sequence entry, e.g. "- value", "- key: value", "- - value", "-"
+	if s == "-" || strings.HasPrefix(s, "- ") {
+		rest := s[1:]
+		spaces := findIndent(rest)
+		if spaces == len(rest) {
+			return s
+		}
+		item := rest[spaces:]
+		if item == "-" || strings.HasPrefix(item, "- ") || splitYamlKey(item) != "" {
+			return s[:1+spaces] + yp.colorizeNode(item, col+1+spaces)
+		}
+		// a scalar in a sequence continues while lines are indented more than the dash
+		return s[:1+spaces] + yp.colorizeValue(item, col, col/2+1)
+	}
 
-	format := "%s"
-	if hasColon {
-		format += ":"
+	key := splitYamlKey(s)
+	if key == "" {
+		return yp.colorizeValue(s, col, col/2)
 	}
 
-	if hasLeadingDash {
-		format = "- " + format
-		indentCnt += 2
+	return yp.colorizeKey(key, col) + ":" + yp.colorizeValue(s[len(key)+1:], col, col/2+1)
+}
+
+// colorizeKey colorizes a key of a mapping by its column.
+func (yp *YamlPrinter) colorizeKey(key string, col int) string {
+	c := getColorByKeyIndent(col, 2, yp.Theme)
+	if q := key[0]; q == '"' || q == '\'' {
+		return string(q) + color.Apply(key[1:len(key)-1], c) + string(q)
 	}
 
-	return fmt.Sprintf(format, color.Apply(key, getColorByKeyIndent(indentCnt, basicWidth, dark)))
+	return color.Apply(key, c)
 }
 
-func (yp *YamlPrinter) toColorizedYamlValue(value string, dark bool) string {
-	if value == "{}" {
-		return "{}"
+// colorizeValue colorizes a value, e.g. " value # comment", " &anchor", " !!tag |".
+// A scalar in it continues to the following lines while they are indented more than parent.
+// keyDepth is the depth of keys in a flow collection if it's started.
+func (yp *YamlPrinter) colorizeValue(s string, parent, keyDepth int) string {
+	var sb strings.Builder
+	for s != "" {
+		switch {
+		case s[0] == ' ' || s[0] == '\t':
+			sb.WriteByte(s[0])
+			s = s[1:]
+			continue
+
+		case s[0] == '#':
+			sb.WriteString(color.Apply(s, yp.Theme.Comment))
+			return sb.String()
+
+		case s[0] == '"' || s[0] == '\'':
+			// if it's not closed, the following lines are a part of it until the closing quote
+			yp.quote, yp.scalarParent = s[0], parent
+			sb.WriteByte(s[0])
+			sb.WriteString(yp.colorizeQuotedRest(s[1:]))
+			return sb.String()
+
+		case s[0] == '{' || s[0] == '[':
+			yp.flowKeyDepth = keyDepth
+			sb.WriteString(yp.colorizeFlow(s))
+			return sb.String()
+		}
+
+		token, rest := cutYamlToken(s)
+		switch token[0] {
+		case '!':
+			sb.WriteString(color.Apply(token, yp.Theme.Tag))
+		case '&', '*':
+			sb.WriteString(color.Apply(token, yp.Theme.Anchor))
+		case '|', '>':
+			// block scalar indicator, e.g. "|", "|-", ">+", "|2"
+			sb.WriteString(token)
+			yp.inBlockScalar, yp.scalarParent = true, parent
+		default:
+			// plain scalar continues until a comment
+			scalar := s
+			if i := strings.Index(s, " #"); i >= 0 {
+				scalar = s[:i]
+			}
+			rest = s[len(scalar):]
+			trimmed := strings.TrimRight(scalar, " \t")
+			sb.WriteString(color.Apply(trimmed, getColorByValueType(trimmed, yp.Theme)))
+			sb.WriteString(scalar[len(trimmed):])
+			yp.inPlainScalar, yp.scalarParent = true, parent
+		}
+		s = rest
 	}
 
-	hasLeadingDash := strings.HasPrefix(value, "- ")
-	value = strings.TrimPrefix(value, "- ")
+	return sb.String()
+}
 
-	isDoubleQuoted := strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`)
-	trimmedValue := strings.TrimSuffix(strings.TrimPrefix(value, `"`), `"`)
+// colorizeQuotedRest colorizes the rest of a quoted scalar after its opening quote (or the start of a continued line).
+// When the closing quote is found, what follows it is colorized as a value.
+func (yp *YamlPrinter) colorizeQuotedRest(s string) string {
+	end := findClosingQuote(s, yp.quote)
+	if end < 0 {
+		return color.Apply(s, yp.Theme.String)
+	}
 
-	var format string
-	switch {
-	case hasLeadingDash && isDoubleQuoted:
-		format = `- "%s"`
-	case hasLeadingDash:
-		format = "- %s"
-	case isDoubleQuoted:
-		format = `"%s"`
-	default:
-		format = "%s"
+	q := yp.quote
+	yp.quote = 0
+	rest := s[end+1:]
+	if yp.flowDepth > 0 {
+		rest = yp.colorizeFlow(rest)
+	} else {
+		rest = yp.colorizeValue(rest, yp.scalarParent, 0)
 	}
 
-	return fmt.Sprintf(format, color.Apply(trimmedValue, getColorByValueType(value, dark)))
+	return applyNonEmpty(s[:end], yp.Theme.String) + string(q) + rest
 }
 
-func (yp *YamlPrinter) toColorizedStringValue(value string, dark bool) string {
-	c := StringColorForLight
-	if dark {
-		c = StringColorForDark
+// colorizeFlow colorizes a flow collection, e.g. "{a: 1, b: [x, y]}".
+// It can be broken into several lines, so the depth is remembered over lines.
+func (yp *YamlPrinter) colorizeFlow(s string) string {
+	var sb strings.Builder
+	for s != "" {
+		switch c := s[0]; {
+		case c == '{' || c == '[':
+			yp.flowDepth++
+			sb.WriteByte(c)
+			s = s[1:]
+
+		case c == '}' || c == ']':
+			if yp.flowDepth > 0 {
+				yp.flowDepth--
+			}
+			sb.WriteByte(c)
+			s = s[1:]
+			if yp.flowDepth == 0 {
+				sb.WriteString(yp.colorizeValue(s, 0, 0))
+				return sb.String()
+			}
+
+		case c == ',' || c == ':' || c == ' ' || c == '\t':
+			sb.WriteByte(c)
+			s = s[1:]
+
+		case c == '#':
+			sb.WriteString(color.Apply(s, yp.Theme.Comment))
+			return sb.String()
+
+		case c == '"' || c == '\'':
+			end := findClosingQuote(s[1:], c)
+			if end < 0 {
+				yp.quote = c
+				sb.WriteByte(c)
+				sb.WriteString(color.Apply(s[1:], yp.Theme.String))
+				return sb.String()
+			}
+			quoted := s[:end+2]
+			s = s[end+2:]
+			if isFlowKey(s) {
+				sb.WriteString(string(c) + applyNonEmpty(quoted[1:len(quoted)-1], yp.flowKeyColor()) + string(c))
+			} else {
+				sb.WriteString(string(c) + applyNonEmpty(quoted[1:len(quoted)-1], yp.Theme.String) + string(c))
+			}
+
+		default:
+			scalar := cutFlowScalar(s)
+			s = s[len(scalar):]
+			switch {
+			case scalar[0] == '!':
+				sb.WriteString(color.Apply(scalar, yp.Theme.Tag))
+			case scalar[0] == '&' || scalar[0] == '*':
+				sb.WriteString(color.Apply(scalar, yp.Theme.Anchor))
+			case isFlowKey(s):
+				sb.WriteString(color.Apply(scalar, yp.flowKeyColor()))
+			default:
+				sb.WriteString(color.Apply(scalar, getColorByValueType(scalar, yp.Theme)))
+			}
+		}
 	}
 
-	isDoubleQuoted := strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`)
-	trimmedValue := strings.TrimRight(strings.TrimLeft(value, `"`), `"`)
+	return sb.String()
+}
 
-	var format string
-	switch {
-	case isDoubleQuoted:
-		format = `"%s"`
+// flowKeyColor returns the color of keys in the current flow mapping.
+func (yp *YamlPrinter) flowKeyColor() color.Color {
+	return getColorByKeyIndent(yp.flowKeyDepth+yp.flowDepth-1, 1, yp.Theme)
+}
+
+// splitYamlKey returns the key of a mapping entry, e.g. "key" for "key: value", `"a: b"` for `"a: b": value`.
+// It returns an empty string when s is not a mapping entry.
+func splitYamlKey(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	end := -1
+	switch s[0] {
+	case '"', '\'':
+		if i := findClosingQuote(s[1:], s[0]); i >= 0 {
+			end = i + 2
+		}
+	case '-', '?', ':':
+		// they can be a plain scalar only when followed by a non-space, e.g. "-val"
+		if len(s) == 1 || s[1] == ' ' {
+			return ""
+		}
+		end = plainKeyEnd(s)
+	case '#', '&', '*', '!', '|', '>', '%', '@', '`', '{', '[', ']', '}', ',':
+		return ""
 	default:
-		format = "%s"
+		end = plainKeyEnd(s)
 	}
-	return fmt.Sprintf(format, color.Apply(trimmedValue, c))
+
+	if end <= 0 || end >= len(s) || s[end] != ':' {
+		return ""
+	}
+	if end+1 < len(s) && s[end+1] != ' ' && s[end+1] != '\t' {
+		return ""
+	}
+
+	return s[:end]
+}
+
+// plainKeyEnd returns where a plain key ends, which is ": " or ":" at the end. It returns -1 if not found.
+func plainKeyEnd(s string) int {
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == ':' && (i+1 == len(s) || s[i+1] == ' ' || s[i+1] == '\t'):
+			return i
+		case s[i] == '#' && i > 0 && s[i-1] == ' ':
+			// a comment starts before the colon
+			return -1
+		}
+	}
+
+	return -1
+}
+
+// findClosingQuote returns the index of the closing quote in s, which is after the opening quote.
+// In double-quoted scalars, a quote is escaped by a backslash. In single-quoted ones, it's escaped by another quote.
+// It returns -1 if not found.
+func findClosingQuote(s string, quote byte) int {
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote == '"' && s[i] == '\\':
+			i++
+		case s[i] == quote && quote == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+		case s[i] == quote:
+			return i
+		}
+	}
+
+	return -1
+}
+
+// cutYamlToken cuts a token which ends with a space.
+func cutYamlToken(s string) (string, string) {
+	if i := strings.IndexAny(s, " \t"); i >= 0 {
+		return s[:i], s[i:]
+	}
+
+	return s, ""
+}
+
+// cutFlowScalar returns a plain scalar in a flow collection which ends with ",", "]", "}" or ": ".
+func cutFlowScalar(s string) string {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case ',', '[', ']', '{', '}':
+			if i > 0 {
+				return strings.TrimRight(s[:i], " \t")
+			}
+			return s[:1]
+		case ':':
+			if i+1 == len(s) || strings.IndexByte(" \t,]}", s[i+1]) >= 0 {
+				return strings.TrimRight(s[:i], " \t")
+			}
+		case '#':
+			if i > 0 && s[i-1] == ' ' {
+				return strings.TrimRight(s[:i], " \t")
+			}
+		}
+	}
+
+	return strings.TrimRight(s, " \t")
 }
 
-func (yp *YamlPrinter) isStringClosed(line string) bool {
-	return strings.HasSuffix(line, "'") || strings.HasSuffix(line, `"`)
+// isFlowKey returns true if what follows a scalar in a flow collection means the scalar is a key.
+func isFlowKey(rest string) bool {
+	rest = strings.TrimLeft(rest, " \t")
+	return strings.HasPrefix(rest, ":") && (len(rest) == 1 || strings.IndexByte(" \t,]}", rest[1]) >= 0)
 }
 
-func (yp *YamlPrinter) isStringOpenedButNotClosed(line string) bool {
-	return (strings.HasPrefix(line, "'") && !strings.HasSuffix(line, "'")) ||
-		(strings.HasPrefix(line, `"`) && !strings.HasSuffix(line, `"`))
+// applyNonEmpty is color.Apply but it doesn't colorize an empty string, e.g. `""`, to print nothing unnecessary.
+func applyNonEmpty(s string, c color.Color) string {
+	if s == "" {
+		return s
+	}
+
+	return color.Apply(s, c)
+}
+
+// isDocumentMarker returns true if the line starts with a document marker, "---" or "...".
+func isDocumentMarker(line string) bool {
+	for _, marker := range []string{"---", "..."} {
+		if line == marker || strings.HasPrefix(line, marker+" ") {
+			return true
+		}
+	}
+
+	return false
 }
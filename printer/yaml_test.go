@@ -2,6 +2,7 @@ package printer
 
 import (
 	"bytes"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -10,14 +11,14 @@ import (
 
 func Test_YamlPrinter_Print(t *testing.T) {
 	tests := []struct {
-		name           string
-		darkBackground bool
-		input          string
-		expected       string
+		name     string
+		theme    *Theme
+		input    string
+		expected string
 	}{
 		{
-			name:           "values can be colored by its type",
-			darkBackground: true,
+			name:  "values can be colored by its type",
+			theme: DarkTheme(),
 			input: testutil.NewHereDoc(`
 				apiVersion: v1
 				kind: "Pod"
@@ -29,14 +30,14 @@ func Test_YamlPrinter_Print(t *testing.T) {
 				[33mapiVersion[0m: [36mv1[0m
 				[33mkind[0m: "[36mPod[0m"
 				[33mnum[0m: [35m415[0m
-				[33munknown[0m: [33m<unknown>[0m
-				[33mnone[0m: [33m<none>[0m
+				[33munknown[0m: [2m<unknown>[0m
+				[33mnone[0m: [2m<none>[0m
 				[33mbool[0m: [32mtrue[0m
 			`),
 		},
 		{
-			name:           "key color changes based on its indentation",
-			darkBackground: true,
+			name:  "key color changes based on its indentation",
+			theme: DarkTheme(),
 			input: testutil.NewHereDoc(`
 				apiVersion: v1
 				items:
@@ -58,8 +59,8 @@ func Test_YamlPrinter_Print(t *testing.T) {
 			`),
 		},
 		{
-			name:           "elements in an array can be colored",
-			darkBackground: true,
+			name:  "elements in an array can be colored",
+			theme: DarkTheme(),
 			input: testutil.NewHereDoc(`
 				lifecycle:
 				  preStop:
@@ -79,8 +80,8 @@ func Test_YamlPrinter_Print(t *testing.T) {
 			`),
 		},
 		{
-			name:           "a value contains dash",
-			darkBackground: true,
+			name:  "a value contains dash",
+			theme: DarkTheme(),
 			input: testutil.NewHereDoc(`
 				apiVersion: v1
 				items:
@@ -102,8 +103,8 @@ func Test_YamlPrinter_Print(t *testing.T) {
 			`),
 		},
 		{
-			name:           "a long string which is broken into several lines can be colored",
-			darkBackground: true,
+			name:  "a long string which is broken into several lines can be colored",
+			theme: DarkTheme(),
 			input: testutil.NewHereDoc(`
 				- apiVersion: v1
 				  kind: Pod
@@ -120,14 +121,117 @@ func Test_YamlPrinter_Print(t *testing.T) {
 				  [37mkind[0m: [36mPod[0m
 				  [37mmetadata[0m:
 				    [33mannotations[0m:
-				      [37mannotation.long.1[0m: [36m'Sometimes, you may want to specify what to command to use as kubectl.[0m
-				        [36mFor example, when you want to use a versioned-kubectl kubectl.1.17, you can do that by an environment variable.'[0m
+				      [37mannotation.long.1[0m: '[36mSometimes, you may want to specify what to command to use as kubectl.[0m
+				        [36mFor example, when you want to use a versioned-kubectl kubectl.1.17, you can do that by an environment variable.[0m'
 				      [37mannotation.long.2[0m: [36mkubecolor colorizes your kubectl command output and does nothing else.[0m
 				        [36mkubecolor internally calls kubectl command and try to colorizes the output so you can use kubecolor as a[0m
 				        [36mcomplete alternative of kubectl[0m
 				      [37mannotation.short.1[0m: [36mnormal length annotation[0m
 			`),
 		},
+		{
+			name:  "block scalars are colored as strings",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				data:
+				  script.sh: |
+				    #!/bin/sh
+				    echo "key: value"
+
+				    exit 0
+				  folded: >-
+				    long text: here
+				kind: ConfigMap`),
+			expected: testutil.NewHereDoc(`
+				[33mdata[0m:
+				  [37mscript.sh[0m: |
+				    [36m#!/bin/sh[0m
+				    [36mecho "key: value"[0m
+
+				    [36mexit 0[0m
+				  [37mfolded[0m: >-
+				    [36mlong text: here[0m
+				[33mkind[0m: [36mConfigMap[0m
+			`),
+		},
+		{
+			name:  "block scalars in a sequence",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				command:
+				- sh
+				- -c
+				- |
+				  echo hi
+				  exit 1
+				args: []`),
+			expected: testutil.NewHereDoc(`
+				[33mcommand[0m:
+				- [36msh[0m
+				- [36m-c[0m
+				- |
+				  [36mecho hi[0m
+				  [36mexit 1[0m
+				[33margs[0m: []
+			`),
+		},
+		{
+			name:  "documents and comments",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				# a comment
+				---
+				kind: Pod # trailing comment
+				url: http://example.com:8080/path
+				...
+				---
+				kind: Service`),
+			expected: testutil.NewHereDoc(`
+				[2m# a comment[0m
+				[1;37m---[0m
+				[33mkind[0m: [36mPod[0m [2m# trailing comment[0m
+				[33murl[0m: [36mhttp://example.com:8080/path[0m
+				[1;37m...[0m
+				[1;37m---[0m
+				[33mkind[0m: [36mService[0m
+			`),
+		},
+		{
+			name:  "anchors, aliases and tags",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				base: &base
+				  replicas: 3
+				derived:
+				  <<: *base
+				  tagged: !!str 123`),
+			expected: testutil.NewHereDoc(`
+				[33mbase[0m: [34m&base[0m
+				  [37mreplicas[0m: [35m3[0m
+				[33mderived[0m:
+				  [37m<<[0m: [34m*base[0m
+				  [37mtagged[0m: [3;35m!!str[0m [35m123[0m
+			`),
+		},
+		{
+			name:  "flow style and quoted keys",
+			theme: DarkTheme(),
+			input: testutil.NewHereDoc(`
+				flow: {a: 1, "b": [x, "y, z"], c: null}
+				list: [1, 2,
+				  3]
+				"quoted: key": 'it''s'
+				multi: "line one
+				  line two" # done`),
+			expected: testutil.NewHereDoc(`
+				[33mflow[0m: {[37ma[0m: [35m1[0m, "[37mb[0m": [[36mx[0m, "[36my, z[0m"], [37mc[0m: [33mnull[0m}
+				[33mlist[0m: [[35m1[0m, [35m2[0m,
+				  [35m3[0m]
+				"[33mquoted: key[0m": '[36mit''s[0m'
+				[33mmulti[0m: "[36mline one[0m
+				  [36mline two[0m" [2m# done[0m
+			`),
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -135,9 +239,36 @@ func Test_YamlPrinter_Print(t *testing.T) {
 			t.Parallel()
 			r := strings.NewReader(tt.input)
 			var w bytes.Buffer
-			printer := YamlPrinter{DarkBackground: tt.darkBackground}
+			printer := YamlPrinter{Theme: tt.theme}
 			printer.Print(r, &w)
 			testutil.MustEqual(t, tt.expected, w.String())
 		})
 	}
 }
+
+func Test_YamlPrinter_Print_KeepsText(t *testing.T) {
+	input := testutil.NewHereDoc(`
+		# comment
+		---
+		apiVersion: v1
+		data:
+		  script.sh: |
+		    echo "key: value" # not a comment
+
+		  flow: {a: 1, "b": [x, "y, z"]}
+		  "quoted": 'it''s'
+		items:
+		- - nested
+		- name: &anchor x
+		  ref: *anchor
+		  tagged: !!str 1
+		  multi: "line one
+		    line two"
+		--- |
+		  root
+	`)
+	var w bytes.Buffer
+	printer := YamlPrinter{Theme: DarkTheme()}
+	printer.Print(strings.NewReader(input), &w)
+	testutil.MustEqual(t, input, regexp.MustCompile("\x1b\\[[0-9;]*m").ReplaceAllString(w.String(), ""))
+}